@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"soosa/internal/commands"
 	"soosa/internal/database"
@@ -55,13 +56,26 @@ func main() {
 			log.Printf("Warning: Failed to load Wordle words: %v", err)
 		} else {
 			log.Println("Wordle words loaded successfully.")
+			if err := commands.LoadWordlePacks(cfg.WordlePacksDir); err != nil {
+				log.Printf("Warning: Failed to load Wordle packs: %v", err)
+			}
+			commands.StartWordleWordWatcher()
 		}
 	} else {
 		log.Println("Warning: Wordle paths not configured. Wordle features disabled.")
 	}
 
+	// 6. Initialize Trivia
+	if err := commands.LoadTriviaQuestions(cfg.TriviaQuestionsPath); err != nil {
+		log.Printf("Warning: Failed to load trivia questions: %v", err)
+	} else {
+		log.Println("Trivia questions loaded successfully.")
+	}
+
 	// Inject DB and OwnerID into commands package
 	commands.DB = db
+	commands.PokerSpectatorDelay = time.Duration(cfg.PokerSpectatorDelay) * time.Second
+	commands.ProvablyFair = cfg.ProvablyFair
 	// TODO: Make this configurable. For now, we might need to fetch it from the bot application info or config. Check if OwnerID is in config, otherwise fetch from Application info
 
 	app, err := bot.Session.Application("@me")
@@ -80,7 +94,9 @@ func main() {
 	// 5. Initialize Player Manager
 	if subClient != nil {
 		mgr := player.NewManager(bot.Session, subClient, cfg)
+		mgr.SetEventHandler(commands.HandlePlayerEvent)
 		commands.PlayerManager = mgr
+		commands.StartLocalFileIndex(cfg.MusicDir)
 	}
 
 	// 5. Register Event Handlers
@@ -111,6 +127,8 @@ func main() {
 	commands.RegisterCommands(bot.Session, cfg.GuildID)
 	log.Println("Internal commands registered.")
 
+	commands.StartWordleResultsScheduler(bot.Session)
+
 	// 8. Wait for Shutdown Signal
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
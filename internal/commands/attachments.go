@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// downloadAttachment fetches an uploaded Discord attachment, reading at most limit bytes.
+func downloadAttachment(url string, limit int64) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, limit))
+}
@@ -62,6 +62,7 @@ type BlackjackGame struct {
 	BaseBet     int
 	StartTime   time.Time
 	LobbyTimer  *time.Timer
+	Fair        *FairDeal // Set when the round was dealt in provably fair mode
 }
 
 var (
@@ -147,6 +148,7 @@ func startBlackjackLobby(s *discordgo.Session, i *discordgo.InteractionCreate, d
 		BaseBet:   bet,
 		Players:   []*BJPlayer{{UserID: i.Member.User.ID, Username: i.Member.User.Username, Bet: bet, Status: StatusWaiting}},
 		StartTime: time.Now().Add(lobbyTimeout),
+		Fair:      newFairCommitment(),
 	}
 	log.Printf("[BLACKJACK LOBBY] Created by %s | Bet: %d", i.Member.User.Username, bet)
 
@@ -194,7 +196,7 @@ func createLobbyEmbed(g *BlackjackGame) *discordgo.MessageEmbed {
 		playersList = append(playersList, fmt.Sprintf("- %s", p.Username))
 	}
 
-	return &discordgo.MessageEmbed{
+	embed := &discordgo.MessageEmbed{
 		Title:       "Blackjack Lobby",
 		Description: fmt.Sprintf("Waiting for players... Starts <t:%d:R>\n\n**Players (%d/%d):**\n%s", g.StartTime.Unix(), len(g.Players), maxPlayers, strings.Join(playersList, "\n")),
 		Color:       0x5865F2,
@@ -202,15 +204,19 @@ func createLobbyEmbed(g *BlackjackGame) *discordgo.MessageEmbed {
 			{Name: "Bet", Value: fmt.Sprintf("$%d", g.BaseBet), Inline: true},
 		},
 	}
+	if g.Fair != nil {
+		embed.Fields = append(embed.Fields, fairLobbyField(g.Fair))
+	}
+	return embed
 }
 
 func createLobbyButtons() []discordgo.MessageComponent {
 	return []discordgo.MessageComponent{
 		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
+			Components: append([]discordgo.MessageComponent{
 				discordgo.Button{Label: "Join", Style: discordgo.PrimaryButton, CustomID: "game_bj_join"},
 				discordgo.Button{Label: "Start", Style: discordgo.SuccessButton, CustomID: "game_bj_start"},
-			},
+			}, fairSeedButton("game_bj_seed")...),
 		},
 	}
 }
@@ -250,6 +256,8 @@ func HandleGameComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		handleJoin(s, i, game)
 	case "game_bj_start":
 		handleStartButton(s, i, game)
+	case "game_bj_seed":
+		handleBjSeedButton(s, i, game)
 	case "game_bj_hit":
 		handleHit(s, i, game)
 	case "game_bj_stand":
@@ -288,6 +296,7 @@ func handleBjPlayAgain(s *discordgo.Session, i *discordgo.InteractionCreate, cus
 		BaseBet:   buyin,
 		Players:   []*BJPlayer{{UserID: i.Member.User.ID, Username: i.Member.User.Username, Bet: buyin, Status: StatusWaiting}},
 		StartTime: time.Now().Add(lobbyTimeout),
+		Fair:      newFairCommitment(),
 	}
 
 	embed := createLobbyEmbed(newGame)
@@ -428,9 +437,51 @@ func handleStartButton(s *discordgo.Session, i *discordgo.InteractionCreate, g *
 	startGame(s, g)
 }
 
+// handleBjSeedButton asks a player for a client seed while the lobby is open.
+func handleBjSeedButton(s *discordgo.Session, i *discordgo.InteractionCreate, g *BlackjackGame) {
+	gamesMutex.Lock()
+	open := g.State == StateLobby && g.Fair != nil
+	gamesMutex.Unlock()
+	if !open {
+		respondError(s, i, "Seeds can only be added before the deal.")
+		return
+	}
+	openFairSeedModal(s, i, "game_bj_seed_modal_"+g.MessageID)
+}
+
+// HandleBlackjackModal adds a submitted client seed to the lobby's deal.
+func HandleBlackjackModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	msgID, ok := strings.CutPrefix(i.ModalSubmitData().CustomID, "game_bj_seed_modal_")
+	if !ok {
+		return
+	}
+
+	gamesMutex.Lock()
+	defer gamesMutex.Unlock()
+
+	g, exists := activeGames[msgID]
+	if !exists || g.State != StateLobby || g.Fair == nil {
+		respondError(s, i, "Seeds can only be added before the deal.")
+		return
+	}
+	if err := g.Fair.AddClientSeed(fairSeedModalValue(i)); err != nil {
+		respondError(s, i, "Couldn't add that seed: "+err.Error()+".")
+		return
+	}
+	log.Printf("[BLACKJACK FAIR] %s added a client seed to game %s", i.Member.User.Username, g.MessageID)
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{createLobbyEmbed(g)},
+			Components: createLobbyButtons(),
+		},
+	})
+}
+
 func startGame(s *discordgo.Session, g *BlackjackGame) {
 	g.State = StatePlaying
-	g.Deck = NewDeck()
+	g.Deck = gameDeck(g.Fair)
 	if len(g.Deck) < 10 { // Safety check, though NewDeck returns 52
 		log.Println("Error: Deck generation failed")
 		return
@@ -457,8 +508,9 @@ func startGame(s *discordgo.Session, g *BlackjackGame) {
 
 func updateGameMessage(s *discordgo.Session, g *BlackjackGame, i *discordgo.InteractionCreate) {
 	embed := &discordgo.MessageEmbed{
-		Title: "Blackjack",
-		Color: 0x00FF00,
+		Title:  "Blackjack",
+		Color:  0x00FF00,
+		Footer: fairDealFooter(g.Fair),
 	}
 
 	dealerHandStr := fmt.Sprintf("`%s%s` `??`", g.Dealer[0].Value, g.Dealer[0].Suit)
@@ -626,7 +678,7 @@ func finishGame(s *discordgo.Session, g *BlackjackGame, i *discordgo.Interaction
 
 	embed := &discordgo.MessageEmbed{
 		Title:       "Blackjack - Results",
-		Description: "**Dealer Finished!**" + resultsSummary,
+		Description: "**Dealer Finished!**" + resultsSummary + fairDealReveal(g.Fair),
 		Color:       0x00FF00,
 	}
 
@@ -0,0 +1,65 @@
+package commands
+
+import "testing"
+
+func TestCalculateBlackjackHand(t *testing.T) {
+	tests := []struct {
+		name string
+		hand string
+		want int
+	}{
+		{"empty", "", 0},
+		{"blackjack", "As Kd", 21},
+		{"ten counts as ten", "Th 9c", 19},
+		{"face cards", "Jc Qd Kh", 30},
+		{"soft seventeen", "Ac 6d", 17},
+		{"soft hand turns hard", "Ac 6d Tc", 17},
+		{"two aces", "Ac Ad", 12},
+		{"three aces", "Ac Ad Ah", 13},
+		{"four aces and a seven", "Ac Ad Ah As 7c", 21},
+		{"soft twenty-one with two aces", "Ac Ad 9h", 21},
+		{"two aces both hard", "Ac Ad 9h Tc", 21},
+		{"bust with ace", "Ac 9d 5h Tc", 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateBlackjackHand(parseCards(t, tt.hand)); got != tt.want {
+				t.Errorf("CalculateBlackjackHand(%s) = %d, want %d", tt.hand, got, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzCalculateBlackjackHand(f *testing.F) {
+	f.Add([]byte{12, 25, 38, 51})
+	f.Add([]byte{12, 8, 3})
+	f.Add([]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		hand := fuzzCards(data, 12)
+
+		hard, aces := 0, 0
+		for _, c := range hand {
+			v := GetBlackjackCardValue(c)
+			if c.Value == "A" {
+				v = 1
+				aces++
+			}
+			hard += v
+		}
+
+		got := CalculateBlackjackHand(hand)
+		if got < hard || got > hard+10*aces {
+			t.Fatalf("%v scored %d, outside %d..%d", hand, got, hard, hard+10*aces)
+		}
+		if (got-hard)%10 != 0 {
+			t.Fatalf("%v scored %d, not hard total %d plus aces at 11", hand, got, hard)
+		}
+		// Only one ace can ever count as 11, and only when that doesn't bust
+		if got > hard+10 || (got > 21 && got != hard) {
+			t.Fatalf("%v scored %d, hard total %d", hand, got, hard)
+		}
+		if got == hard && aces > 0 && hard+10 <= 21 {
+			t.Fatalf("%v scored %d, an ace should count as 11", hand, got)
+		}
+	})
+}
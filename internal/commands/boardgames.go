@@ -0,0 +1,716 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// boardRules describes one grid game. Gravity games drop pieces to the lowest free row of a column.
+type boardRules struct {
+	Name      string // Command name and stats key
+	Title     string
+	Rows      int
+	Cols      int
+	WinLength int
+	Gravity   bool
+	Empty     string
+	Pieces    [2]string
+}
+
+var (
+	ticTacToeRules = &boardRules{
+		Name: "tictactoe", Title: "Tic-Tac-Toe",
+		Rows: 3, Cols: 3, WinLength: 3,
+		Empty: "⬜", Pieces: [2]string{"❌", "⭕"},
+	}
+	connectFourRules = &boardRules{
+		Name: "connect4", Title: "Connect Four",
+		Rows: 6, Cols: 7, WinLength: 4, Gravity: true,
+		Empty: "⚫", Pieces: [2]string{"🔴", "🟡"},
+	}
+	boardGameRules = map[string]*boardRules{
+		ticTacToeRules.Name:   ticTacToeRules,
+		connectFourRules.Name: connectFourRules,
+	}
+)
+
+var BoardGameCommands = []*discordgo.ApplicationCommand{
+	boardGameCommand(ticTacToeRules),
+	boardGameCommand(connectFourRules),
+}
+
+func boardGameCommand(rules *boardRules) *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:        rules.Name,
+		Description: "Play " + rules.Title + " against another player",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "play",
+				Description: "Challenge a player, or leave the game open for anyone",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "opponent",
+						Description: "Player to challenge (anyone can join if left out)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "bet",
+						Description: "Amount each player puts in; the winner takes both (default 0)",
+						Required:    false,
+						MinValue:    &[]float64{0}[0],
+					},
+				},
+			},
+			{
+				Name:        "stats",
+				Description: "Show " + rules.Title + " wins and losses",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "user",
+						Description: "Player to look up (defaults to you)",
+						Required:    false,
+					},
+				},
+			},
+		},
+	}
+}
+
+type BoardGame struct {
+	MessageID  string
+	ChannelID  string
+	Rules      *boardRules
+	HostID     string
+	OpponentID string    // Invited player; empty for an open challenge
+	Players    [2]string // Players[0] moves first and plays Pieces[0]
+	Cells      []int     // Row-major; 0 is empty, otherwise the player index + 1
+	Turn       int
+	Bet        int
+	State      GameState
+	Timer      *time.Timer
+	Result     string
+}
+
+var (
+	activeBoardGames = make(map[string]*BoardGame) // By message ID
+	boardMutex       sync.Mutex
+	boardLobbyWait   = 2 * time.Minute
+	boardTurnTimeout = 2 * time.Minute
+)
+
+// dropRow returns the row a piece dropped in col lands on, or -1 if the column is full.
+func dropRow(cells []int, rows, cols, col int) int {
+	for row := rows - 1; row >= 0; row-- {
+		if cells[row*cols+col] == 0 {
+			return row
+		}
+	}
+	return -1
+}
+
+// boardWinner returns the player (1 or 2) with WinLength in a row horizontally, vertically or diagonally, or 0.
+func boardWinner(cells []int, rows, cols, need int) int {
+	directions := [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			p := cells[row*cols+col]
+			if p == 0 {
+				continue
+			}
+			for _, d := range directions {
+				n := 1
+				for ; n < need; n++ {
+					r, c := row+d[0]*n, col+d[1]*n
+					if r < 0 || r >= rows || c < 0 || c >= cols || cells[r*cols+c] != p {
+						break
+					}
+				}
+				if n == need {
+					return p
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func boardFull(cells []int) bool {
+	for _, c := range cells {
+		if c == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// renderBoard draws the grid as emoji, with column numbers above gravity boards.
+func renderBoard(g *BoardGame) string {
+	var sb strings.Builder
+	if g.Rules.Gravity {
+		for col := 0; col < g.Rules.Cols; col++ {
+			sb.WriteString(fmt.Sprintf("%d️⃣", col+1))
+		}
+		sb.WriteString("\n")
+	}
+	for row := 0; row < g.Rules.Rows; row++ {
+		for col := 0; col < g.Rules.Cols; col++ {
+			if p := g.Cells[row*g.Rules.Cols+col]; p > 0 {
+				sb.WriteString(g.Rules.Pieces[p-1])
+			} else {
+				sb.WriteString(g.Rules.Empty)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func HandleBoardGameCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	rules := boardGameRules[data.Name]
+	if rules == nil || len(data.Options) == 0 {
+		return
+	}
+	sub := data.Options[0]
+
+	switch sub.Name {
+	case "play":
+		opponentID := ""
+		bet := 0
+		for _, opt := range sub.Options {
+			switch opt.Name {
+			case "opponent":
+				opponent := opt.UserValue(s)
+				if opponent == nil || opponent.ID == i.Member.User.ID || opponent.Bot {
+					respondError(s, i, "Pick another player to challenge.")
+					return
+				}
+				opponentID = opponent.ID
+			case "bet":
+				bet = int(opt.IntValue())
+			}
+		}
+		createBoardChallenge(s, i, rules, opponentID, bet)
+	case "stats":
+		handleBoardStats(s, i, rules, sub.Options)
+	}
+}
+
+// createBoardChallenge posts a new lobby. The host's bet is held until the game starts or the challenge lapses.
+func createBoardChallenge(s *discordgo.Session, i *discordgo.InteractionCreate, rules *boardRules, opponentID string, bet int) {
+	hostID := i.Member.User.ID
+	if bet < 0 {
+		respondError(s, i, "Bet cannot be negative.")
+		return
+	}
+
+	if bet > 0 {
+		bal, err := DB.GetBalance(hostID)
+		if err != nil {
+			respondError(s, i, "Database error.")
+			return
+		}
+		if bal < bet {
+			respondError(s, i, "Insufficient funds.")
+			return
+		}
+		if err := DB.AddBalance(hostID, -bet); err != nil {
+			respondError(s, i, "Transaction failed.")
+			return
+		}
+	}
+
+	g := &BoardGame{
+		Rules:      rules,
+		HostID:     hostID,
+		OpponentID: opponentID,
+		Cells:      make([]int, rules.Rows*rules.Cols),
+		Bet:        bet,
+		State:      StateLobby,
+	}
+
+	content := ""
+	if opponentID != "" {
+		content = fmt.Sprintf("<@%s>", opponentID)
+	}
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Embeds:     []*discordgo.MessageEmbed{buildBoardEmbed(g)},
+			Components: createBoardLobbyButtons(g),
+		},
+	})
+	if err != nil {
+		DB.AddBalance(hostID, bet)
+		return
+	}
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		DB.AddBalance(hostID, bet)
+		return
+	}
+	g.MessageID = msg.ID
+	g.ChannelID = msg.ChannelID
+	log.Printf("[BOARD LOBBY] %s | Host: %s | Opponent: '%s' | Bet: %d", rules.Name, hostID, opponentID, bet)
+
+	boardMutex.Lock()
+	activeBoardGames[g.MessageID] = g
+	g.Timer = time.AfterFunc(boardLobbyWait, func() {
+		boardMutex.Lock()
+		defer boardMutex.Unlock()
+		if g.State == StateLobby {
+			cancelBoardGame(s, g, "The challenge expired.", nil)
+		}
+	})
+	boardMutex.Unlock()
+}
+
+func createBoardLobbyButtons(g *BoardGame) []discordgo.MessageComponent {
+	join := "Join"
+	if g.OpponentID != "" {
+		join = "Accept"
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: join, Style: discordgo.SuccessButton, CustomID: "board_accept"},
+				discordgo.Button{Label: "Cancel", Style: discordgo.DangerButton, CustomID: "board_cancel"},
+			},
+		},
+	}
+}
+
+// createBoardMoveButtons lays out one button per cell for small boards and one per column for gravity boards, plus a forfeit button.
+func createBoardMoveButtons(g *BoardGame) []discordgo.MessageComponent {
+	var buttons []discordgo.MessageComponent
+	if g.Rules.Gravity {
+		for col := 0; col < g.Rules.Cols; col++ {
+			buttons = append(buttons, discordgo.Button{
+				Label:    fmt.Sprintf("%d", col+1),
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("board_move:%d", col),
+				Disabled: dropRow(g.Cells, g.Rules.Rows, g.Rules.Cols, col) < 0,
+			})
+		}
+	} else {
+		for idx, p := range g.Cells {
+			b := discordgo.Button{Label: fmt.Sprintf("%d", idx+1), Style: discordgo.SecondaryButton, CustomID: fmt.Sprintf("board_move:%d", idx)}
+			if p > 0 {
+				b.Label = ""
+				b.Emoji = &discordgo.ComponentEmoji{Name: g.Rules.Pieces[p-1]}
+				b.Disabled = true
+			}
+			buttons = append(buttons, b)
+		}
+	}
+
+	// Tic-Tac-Toe keeps the 3x3 shape; Connect Four packs its columns five to a row
+	perRow := 5
+	if !g.Rules.Gravity {
+		perRow = g.Rules.Cols
+	}
+	var rows []discordgo.MessageComponent
+	for start := 0; start < len(buttons); start += perRow {
+		end := min(start+perRow, len(buttons))
+		rows = append(rows, discordgo.ActionsRow{Components: buttons[start:end]})
+	}
+
+	forfeit := discordgo.Button{Label: "Forfeit", Style: discordgo.DangerButton, CustomID: "board_forfeit"}
+	last := rows[len(rows)-1].(discordgo.ActionsRow)
+	if len(last.Components) < 5 && g.Rules.Gravity {
+		last.Components = append(last.Components, forfeit)
+		rows[len(rows)-1] = last
+	} else {
+		rows = append(rows, discordgo.ActionsRow{Components: []discordgo.MessageComponent{forfeit}})
+	}
+	return rows
+}
+
+// createBoardRematchButtons offers the same game and bet again, like createBjPlayAgainButtons.
+func createBoardRematchButtons(g *BoardGame) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Rematch",
+					Style:    discordgo.SuccessButton,
+					CustomID: fmt.Sprintf("board_rematch:%s:%d:%s:%s", g.Rules.Name, g.Bet, g.Players[0], g.Players[1]),
+				},
+			},
+		},
+	}
+}
+
+func buildBoardEmbed(g *BoardGame) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{Title: g.Rules.Title, Color: 0x5865F2}
+	stakes := "No bet"
+	if g.Bet > 0 {
+		stakes = fmt.Sprintf("$%d each, winner takes $%d", g.Bet, g.Bet*2)
+	}
+
+	switch g.State {
+	case StateLobby:
+		if g.OpponentID != "" {
+			embed.Description = fmt.Sprintf("<@%s> challenges <@%s>! Expires <t:%d:R>.", g.HostID, g.OpponentID, time.Now().Add(boardLobbyWait).Unix())
+		} else {
+			embed.Description = fmt.Sprintf("<@%s> wants to play. Anyone can join! Expires <t:%d:R>.", g.HostID, time.Now().Add(boardLobbyWait).Unix())
+		}
+	case StatePlaying:
+		embed.Description = fmt.Sprintf("%s\n%s <@%s> vs %s <@%s>\n\n%s <@%s>'s turn (<t:%d:R> left)",
+			renderBoard(g), g.Rules.Pieces[0], g.Players[0], g.Rules.Pieces[1], g.Players[1],
+			g.Rules.Pieces[g.Turn], g.Players[g.Turn], time.Now().Add(boardTurnTimeout).Unix())
+	case StateFinished:
+		embed.Color = 0x00FF00
+		embed.Description = fmt.Sprintf("%s\n%s <@%s> vs %s <@%s>\n\n%s", renderBoard(g), g.Rules.Pieces[0], g.Players[0], g.Rules.Pieces[1], g.Players[1], g.Result)
+	}
+	embed.Fields = []*discordgo.MessageEmbedField{{Name: "Bet", Value: stakes, Inline: true}}
+	return embed
+}
+
+// updateBoardMessage shows the game's current state, answering the interaction when there is one and editing the message otherwise.
+func updateBoardMessage(s *discordgo.Session, g *BoardGame, i *discordgo.InteractionCreate) {
+	embed := buildBoardEmbed(g)
+	var components []discordgo.MessageComponent
+	switch g.State {
+	case StatePlaying:
+		components = createBoardMoveButtons(g)
+	case StateFinished:
+		if g.Players[1] != "" {
+			components = createBoardRematchButtons(g)
+		}
+	}
+
+	if i != nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embed},
+				Components: components,
+			},
+		})
+		return
+	}
+	embeds := []*discordgo.MessageEmbed{embed}
+	if components == nil {
+		components = []discordgo.MessageComponent{}
+	}
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         g.MessageID,
+		Channel:    g.ChannelID,
+		Embeds:     &embeds,
+		Components: &components,
+	})
+}
+
+func HandleBoardGameComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	if strings.HasPrefix(customID, "board_rematch:") {
+		handleBoardRematch(s, i, customID)
+		return
+	}
+
+	boardMutex.Lock()
+	defer boardMutex.Unlock()
+
+	g, exists := activeBoardGames[i.Message.ID]
+	if !exists {
+		boardEphemeral(s, i, "Game not found.")
+		return
+	}
+
+	switch {
+	case customID == "board_accept":
+		handleBoardAccept(s, i, g)
+	case customID == "board_cancel":
+		if g.State != StateLobby {
+			return
+		}
+		userID := i.Member.User.ID
+		if userID != g.HostID && userID != g.OpponentID {
+			boardEphemeral(s, i, "This challenge isn't for you.")
+			return
+		}
+		reason := "The challenge was withdrawn."
+		if userID == g.OpponentID {
+			reason = "The challenge was declined."
+		}
+		cancelBoardGame(s, g, reason, i)
+	case customID == "board_forfeit":
+		if g.State != StatePlaying {
+			return
+		}
+		switch i.Member.User.ID {
+		case g.Players[0]:
+			finishBoardGame(s, g, 2, fmt.Sprintf("<@%s> forfeited.", g.Players[0]), i)
+		case g.Players[1]:
+			finishBoardGame(s, g, 1, fmt.Sprintf("<@%s> forfeited.", g.Players[1]), i)
+		default:
+			boardEphemeral(s, i, "You're not in this game.")
+		}
+	case strings.HasPrefix(customID, "board_move:"):
+		var target int
+		fmt.Sscanf(customID, "board_move:%d", &target)
+		handleBoardMove(s, i, g, target)
+	}
+}
+
+func boardEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, msg string) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: msg, Flags: discordgo.MessageFlagsEphemeral},
+	})
+}
+
+// handleBoardAccept seats the second player, takes their bet and starts the game. Call with boardMutex held.
+func handleBoardAccept(s *discordgo.Session, i *discordgo.InteractionCreate, g *BoardGame) {
+	userID := i.Member.User.ID
+	if g.State != StateLobby {
+		boardEphemeral(s, i, "Game already started!")
+		return
+	}
+	if userID == g.HostID {
+		boardEphemeral(s, i, "You can't play yourself!")
+		return
+	}
+	if g.OpponentID != "" && userID != g.OpponentID {
+		boardEphemeral(s, i, "This challenge isn't for you.")
+		return
+	}
+
+	if g.Bet > 0 {
+		bal, err := DB.GetBalance(userID)
+		if err != nil || bal < g.Bet {
+			boardEphemeral(s, i, "Insufficient funds!")
+			return
+		}
+		if err := DB.AddBalance(userID, -g.Bet); err != nil {
+			boardEphemeral(s, i, "Transaction failed!")
+			return
+		}
+	}
+
+	if g.Timer != nil {
+		g.Timer.Stop()
+	}
+	// Whoever goes first has the edge, so it's a coin flip
+	g.Players = [2]string{g.HostID, userID}
+	if DefaultShuffler.Intn(2) == 1 {
+		g.Players = [2]string{userID, g.HostID}
+	}
+	g.State = StatePlaying
+	g.Turn = 0
+	log.Printf("[BOARD START] %s %s | %s vs %s | Bet: %d", g.Rules.Name, g.MessageID, g.Players[0], g.Players[1], g.Bet)
+
+	resetBoardTurnTimer(s, g)
+	updateBoardMessage(s, g, i)
+}
+
+// handleBoardMove places the current player's piece on a cell, or in a column for gravity games. Call with boardMutex held.
+func handleBoardMove(s *discordgo.Session, i *discordgo.InteractionCreate, g *BoardGame, target int) {
+	if g.State != StatePlaying {
+		return
+	}
+	if i.Member.User.ID != g.Players[g.Turn] {
+		boardEphemeral(s, i, "It's not your turn!")
+		return
+	}
+
+	cell := target
+	if g.Rules.Gravity {
+		if target < 0 || target >= g.Rules.Cols {
+			return
+		}
+		row := dropRow(g.Cells, g.Rules.Rows, g.Rules.Cols, target)
+		if row < 0 {
+			boardEphemeral(s, i, "That column is full.")
+			return
+		}
+		cell = row*g.Rules.Cols + target
+	}
+	if cell < 0 || cell >= len(g.Cells) || g.Cells[cell] != 0 {
+		boardEphemeral(s, i, "That square is taken.")
+		return
+	}
+	g.Cells[cell] = g.Turn + 1
+
+	if winner := boardWinner(g.Cells, g.Rules.Rows, g.Rules.Cols, g.Rules.WinLength); winner != 0 {
+		finishBoardGame(s, g, winner, fmt.Sprintf("%s <@%s> wins! 🎉", g.Rules.Pieces[winner-1], g.Players[winner-1]), i)
+		return
+	}
+	if boardFull(g.Cells) {
+		finishBoardGame(s, g, 0, "It's a draw! 🤝", i)
+		return
+	}
+
+	g.Turn = 1 - g.Turn
+	resetBoardTurnTimer(s, g)
+	updateBoardMessage(s, g, i)
+}
+
+// resetBoardTurnTimer gives the player to move boardTurnTimeout before they forfeit. Call with boardMutex held.
+func resetBoardTurnTimer(s *discordgo.Session, g *BoardGame) {
+	if g.Timer != nil {
+		g.Timer.Stop()
+	}
+	turn, filled := g.Turn, countBoardPieces(g.Cells)
+	g.Timer = time.AfterFunc(boardTurnTimeout, func() {
+		boardMutex.Lock()
+		defer boardMutex.Unlock()
+		// Only forfeit if nobody has moved since the timer started
+		if g.State != StatePlaying || g.Turn != turn || countBoardPieces(g.Cells) != filled {
+			return
+		}
+		finishBoardGame(s, g, 2-turn, fmt.Sprintf("<@%s> ran out of time.", g.Players[turn]), nil)
+	})
+}
+
+func countBoardPieces(cells []int) int {
+	n := 0
+	for _, c := range cells {
+		if c != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// finishBoardGame pays out, records stats and shows the final board. Winner is 1 or 2, or 0 for a draw. Call with boardMutex held.
+func finishBoardGame(s *discordgo.Session, g *BoardGame, winner int, result string, i *discordgo.InteractionCreate) {
+	g.State = StateFinished
+	g.Result = result
+	if g.Timer != nil {
+		g.Timer.Stop()
+	}
+	delete(activeBoardGames, g.MessageID)
+
+	if winner == 0 {
+		if g.Bet > 0 {
+			DB.AddBalance(g.Players[0], g.Bet)
+			DB.AddBalance(g.Players[1], g.Bet)
+		}
+		if err := DB.RecordBoardResult(g.Rules.Name, g.Players[0], g.Players[1], true); err != nil {
+			log.Printf("[BOARD] Failed to record result: %v", err)
+		}
+	} else {
+		winnerID, loserID := g.Players[winner-1], g.Players[2-winner]
+		if g.Bet > 0 {
+			DB.AddBalance(winnerID, g.Bet*2)
+			g.Result += fmt.Sprintf(" Won $%d.", g.Bet*2)
+		}
+		if err := DB.RecordBoardResult(g.Rules.Name, winnerID, loserID, false); err != nil {
+			log.Printf("[BOARD] Failed to record result: %v", err)
+		}
+	}
+	log.Printf("[BOARD FINISH] %s %s | %s", g.Rules.Name, g.MessageID, result)
+
+	updateBoardMessage(s, g, i)
+
+	// Cleanup button
+	go func(mID, cID string) {
+		time.Sleep(30 * time.Second)
+		s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         mID,
+			Channel:    cID,
+			Components: &[]discordgo.MessageComponent{},
+		})
+	}(g.MessageID, g.ChannelID)
+}
+
+// cancelBoardGame closes a lobby and returns the host's bet. Call with boardMutex held.
+func cancelBoardGame(s *discordgo.Session, g *BoardGame, reason string, i *discordgo.InteractionCreate) {
+	g.State = StateFinished
+	g.Result = reason
+	if g.Timer != nil {
+		g.Timer.Stop()
+	}
+	delete(activeBoardGames, g.MessageID)
+	if g.Bet > 0 {
+		DB.AddBalance(g.HostID, g.Bet)
+	}
+	log.Printf("[BOARD CANCEL] %s %s | %s", g.Rules.Name, g.MessageID, reason)
+
+	embed := &discordgo.MessageEmbed{Title: g.Rules.Title, Description: reason, Color: 0x95a5a6}
+	if i != nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embed},
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+	embeds := []*discordgo.MessageEmbed{embed}
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         g.MessageID,
+		Channel:    g.ChannelID,
+		Embeds:     &embeds,
+		Components: &[]discordgo.MessageComponent{},
+	})
+}
+
+// handleBoardRematch challenges the other player from a finished game to the same game and bet.
+func handleBoardRematch(s *discordgo.Session, i *discordgo.InteractionCreate, customID string) {
+	parts := strings.Split(customID, ":")
+	if len(parts) != 5 {
+		return
+	}
+	rules := boardGameRules[parts[1]]
+	if rules == nil {
+		return
+	}
+	bet := 0
+	fmt.Sscan(parts[2], &bet)
+
+	opponentID := ""
+	switch i.Member.User.ID {
+	case parts[3]:
+		opponentID = parts[4]
+	case parts[4]:
+		opponentID = parts[3]
+	default:
+		boardEphemeral(s, i, "Only the players can ask for a rematch.")
+		return
+	}
+	createBoardChallenge(s, i, rules, opponentID, bet)
+}
+
+func handleBoardStats(s *discordgo.Session, i *discordgo.InteractionCreate, rules *boardRules, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	user := i.Member.User
+	if len(options) > 0 {
+		user = options[0].UserValue(s)
+	}
+
+	stats, err := DB.GetBoardStats(user.ID, rules.Name)
+	if err != nil {
+		respondError(s, i, "Database error.")
+		return
+	}
+
+	played := stats.Wins + stats.Losses + stats.Draws
+	winRate := 0.0
+	if played > 0 {
+		winRate = float64(stats.Wins) / float64(played) * 100
+	}
+	respondEmbed(s, i, &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("%s Stats: %s", rules.Title, user.Username),
+		Color: 0x5865F2,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Played", Value: fmt.Sprintf("%d", played), Inline: true},
+			{Name: "Wins", Value: fmt.Sprintf("%d", stats.Wins), Inline: true},
+			{Name: "Losses", Value: fmt.Sprintf("%d", stats.Losses), Inline: true},
+			{Name: "Draws", Value: fmt.Sprintf("%d", stats.Draws), Inline: true},
+			{Name: "Win %", Value: fmt.Sprintf("%.0f%%", winRate), Inline: true},
+		},
+	})
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// parseBoard turns rows like ".XO" into cells, with X as player 1 and O as player 2.
+func parseBoard(rows ...string) []int {
+	var cells []int
+	for _, row := range rows {
+		for _, r := range row {
+			switch r {
+			case 'X':
+				cells = append(cells, 1)
+			case 'O':
+				cells = append(cells, 2)
+			default:
+				cells = append(cells, 0)
+			}
+		}
+	}
+	return cells
+}
+
+func TestBoardWinner(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules *boardRules
+		rows  []string
+		want  int
+	}{
+		{"empty", ticTacToeRules, []string{"...", "...", "..."}, 0},
+		{"row", ticTacToeRules, []string{"XXX", "OO.", "..."}, 1},
+		{"column", ticTacToeRules, []string{"XO.", "XO.", ".O."}, 2},
+		{"diagonal", ticTacToeRules, []string{"X.O", ".XO", "..X"}, 1},
+		{"anti-diagonal", ticTacToeRules, []string{"X.O", ".OX", "O.."}, 2},
+		{"full draw", ticTacToeRules, []string{"XOX", "XOO", "OXX"}, 0},
+		{"c4 three is not enough", connectFourRules, []string{".......", ".......", ".......", ".......", ".......", "XXX.OOO"}, 0},
+		{"c4 horizontal", connectFourRules, []string{".......", ".......", ".......", ".......", ".......", "..XXXX."}, 1},
+		{"c4 vertical", connectFourRules, []string{".......", ".......", "......O", "......O", "......O", "XX.X..O"}, 2},
+		{"c4 diagonal", connectFourRules, []string{".......", ".......", "...X...", "..XO...", ".XOO...", "XOOX..."}, 1},
+		{"c4 wrapped row is not a line", connectFourRules, []string{".......", ".......", ".......", ".......", "XX.....", ".....XX"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := boardWinner(parseBoard(tt.rows...), tt.rules.Rows, tt.rules.Cols, tt.rules.WinLength)
+			if got != tt.want {
+				t.Errorf("winner = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDropRow(t *testing.T) {
+	cells := parseBoard(
+		"X......",
+		"O......",
+		"X......",
+		"O......",
+		"X.....O",
+		"O.X...X",
+	)
+	tests := []struct{ col, want int }{
+		{0, -1},
+		{1, 5},
+		{2, 4},
+		{6, 3},
+	}
+	for _, tt := range tests {
+		if got := dropRow(cells, 6, 7, tt.col); got != tt.want {
+			t.Errorf("dropRow(col %d) = %d, want %d", tt.col, got, tt.want)
+		}
+	}
+}
+
+func TestBoardMoveButtonsFitDiscordLimits(t *testing.T) {
+	for _, rules := range boardGameRules {
+		g := &BoardGame{Rules: rules, Cells: make([]int, rules.Rows*rules.Cols)}
+		rows := createBoardMoveButtons(g)
+		if len(rows) > 5 {
+			t.Errorf("%s uses %d action rows", rules.Name, len(rows))
+		}
+		moves := 0
+		for _, row := range rows {
+			buttons := row.(discordgo.ActionsRow).Components
+			if len(buttons) > 5 {
+				t.Errorf("%s has %d buttons in a row", rules.Name, len(buttons))
+			}
+			for _, b := range buttons {
+				if strings.HasPrefix(b.(discordgo.Button).CustomID, "board_move:") {
+					moves++
+				}
+			}
+		}
+		want := rules.Rows * rules.Cols
+		if rules.Gravity {
+			want = rules.Cols
+		}
+		if moves != want {
+			t.Errorf("%s has %d move buttons, want %d", rules.Name, moves, want)
+		}
+	}
+}
+
+func TestRenderBoard(t *testing.T) {
+	g := &BoardGame{Rules: ticTacToeRules, Cells: parseBoard("X..", ".O.", "...")}
+	want := "❌⬜⬜\n⬜⭕⬜\n⬜⬜⬜\n"
+	if got := renderBoard(g); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	g = &BoardGame{Rules: connectFourRules, Cells: make([]int, 42)}
+	if lines := strings.Split(strings.TrimSpace(renderBoard(g)), "\n"); len(lines) != 7 {
+		t.Errorf("connect four should render a header and 6 rows, got %d lines", len(lines))
+	}
+}
@@ -0,0 +1,332 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"soosa/internal/database"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var CasinoCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "casino",
+		Description: "Configure roulette and slots payouts for this server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "settings",
+				Description: "Show the current payouts and house edge",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "roulette",
+				Description: "Change roulette payout odds and commission",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "straight",
+						Description: "Odds paid on a single number (default 35, i.e. 35:1)",
+						Required:    false,
+						MinValue:    &[]float64{1}[0],
+						MaxValue:    36,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "dozen",
+						Description: "Odds paid on a dozen (default 2)",
+						Required:    false,
+						MinValue:    &[]float64{1}[0],
+						MaxValue:    3,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "even",
+						Description: "Odds paid on red/black, odd/even and low/high (default 1)",
+						Required:    false,
+						MinValue:    &[]float64{1}[0],
+						MaxValue:    2,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "commission",
+						Description: "Percentage of winnings the house keeps (default 0)",
+						Required:    false,
+						MinValue:    &[]float64{0}[0],
+						MaxValue:    maxCasinoCommission,
+					},
+				},
+			},
+			{
+				Name:        "slots",
+				Description: "Add, change or remove a slot machine symbol, or change the commission",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "symbol",
+						Description: "Emoji to add or change",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "weight",
+						Description: "How often it lands on each reel, relative to the others (0 removes it)",
+						Required:    false,
+						MinValue:    &[]float64{0}[0],
+						MaxValue:    maxSlotWeight,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "three",
+						Description: "Bet multiplier paid for three of a kind",
+						Required:    false,
+						MinValue:    &[]float64{0}[0],
+						MaxValue:    maxSlotMultiplier,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "two",
+						Description: "Bet multiplier paid for a pair on the first two reels",
+						Required:    false,
+						MinValue:    &[]float64{0}[0],
+						MaxValue:    maxSlotMultiplier,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "commission",
+						Description: "Percentage of winnings the house keeps (default 0)",
+						Required:    false,
+						MinValue:    &[]float64{0}[0],
+						MaxValue:    maxCasinoCommission,
+					},
+				},
+			},
+			{
+				Name:        "reset",
+				Description: "Put a game back on the default payouts",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "game",
+						Description: "Game to reset",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Roulette", Value: "roulette"},
+							{Name: "Slots", Value: "slots"},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+const (
+	maxCasinoCommission = 50
+	maxSlotWeight       = 100
+	maxSlotMultiplier   = 10000
+	minSlotSymbols      = 2
+	maxSlotSymbols      = 10
+)
+
+// casinoMutex serialises read-modify-write updates to the casino settings.
+var casinoMutex sync.Mutex
+
+// guildCasinoSettings returns a guild's settings with the defaults filled in for anything it hasn't changed.
+func guildCasinoSettings(guildID string) *database.CasinoSettings {
+	settings, err := DB.GetCasinoSettings(guildID)
+	if err != nil {
+		log.Printf("[CASINO] Failed to load settings for %s, using defaults: %v", guildID, err)
+		settings = &database.CasinoSettings{}
+	}
+	if settings.RoulettePayouts == nil {
+		settings.RoulettePayouts = defaultRoulettePayouts()
+	}
+	if settings.SlotsSymbols == nil {
+		settings.SlotsSymbols = defaultSlotSymbols()
+	}
+	return settings
+}
+
+// afterCommission is what a player keeps of their winnings once the house takes its percentage.
+func afterCommission(winnings, commission int) int {
+	return winnings - winnings*commission/100
+}
+
+func HandleCasinoCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	if len(data.Options) == 0 {
+		return
+	}
+	sub := data.Options[0]
+
+	if sub.Name == "settings" {
+		respondEmbed(s, i, buildCasinoSettingsEmbed(guildCasinoSettings(i.GuildID)))
+		return
+	}
+
+	casinoMutex.Lock()
+	defer casinoMutex.Unlock()
+
+	settings := guildCasinoSettings(i.GuildID)
+	var err error
+	switch sub.Name {
+	case "roulette":
+		err = applyRouletteSettings(settings, sub.Options)
+	case "slots":
+		err = applySlotsSettings(settings, sub.Options)
+	case "reset":
+		if sub.Options[0].StringValue() == "roulette" {
+			settings.RoulettePayouts = defaultRoulettePayouts()
+			settings.RouletteCommission = 0
+		} else {
+			settings.SlotsSymbols = defaultSlotSymbols()
+			settings.SlotsCommission = 0
+		}
+	}
+	if err != nil {
+		respondError(s, i, fmt.Sprintf("Can't change that: %v.", err))
+		return
+	}
+
+	if err := DB.SaveCasinoSettings(i.GuildID, settings); err != nil {
+		log.Printf("[CASINO] Failed to save settings: %v", err)
+		respondError(s, i, "Database error.")
+		return
+	}
+	log.Printf("[CASINO] Guild %s %s settings changed by %s", i.GuildID, sub.Name, i.Member.User.ID)
+
+	embed := buildCasinoSettingsEmbed(settings)
+	embed.Title = "Casino Settings Updated"
+	respondEmbed(s, i, embed)
+}
+
+// applyRouletteSettings changes the payouts in settings, rejecting any bet that would pay players more than it costs on average.
+func applyRouletteSettings(settings *database.CasinoSettings, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	payouts := make(map[string]int, len(settings.RoulettePayouts))
+	for group, odds := range settings.RoulettePayouts {
+		payouts[group] = odds
+	}
+	commission := settings.RouletteCommission
+
+	for _, opt := range options {
+		switch opt.Name {
+		case "straight", "dozen", "even":
+			payouts[opt.Name] = int(opt.IntValue())
+		case "commission":
+			commission = int(opt.IntValue())
+		}
+	}
+
+	for _, group := range rouletteBetGroups {
+		if edge := rouletteHouseEdge(group.Size, rouletteOdds(payouts, group.Name), commission); edge < 0 {
+			return fmt.Errorf("%s bets would pay out %.1f%% more than they take in", group.Name, -edge*100)
+		}
+	}
+	settings.RoulettePayouts = payouts
+	settings.RouletteCommission = commission
+	return nil
+}
+
+// applySlotsSettings adds, changes or removes a symbol in settings, rejecting paytables that would pay out more than they take in.
+func applySlotsSettings(settings *database.CasinoSettings, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	var symbol string
+	var weight, three, two *int
+	commission := settings.SlotsCommission
+	for _, opt := range options {
+		if opt.Name == "symbol" {
+			symbol = strings.TrimSpace(opt.StringValue())
+			continue
+		}
+		v := int(opt.IntValue())
+		switch opt.Name {
+		case "weight":
+			weight = &v
+		case "three":
+			three = &v
+		case "two":
+			two = &v
+		case "commission":
+			commission = v
+		}
+	}
+	if symbol == "" && (weight != nil || three != nil || two != nil) {
+		return fmt.Errorf("pick the symbol to change")
+	}
+
+	symbols := append([]database.SlotSymbol{}, settings.SlotsSymbols...)
+	if symbol != "" {
+		idx := -1
+		for n, sym := range symbols {
+			if sym.Emoji == symbol {
+				idx = n
+			}
+		}
+
+		switch {
+		case weight != nil && *weight == 0:
+			if idx < 0 {
+				return fmt.Errorf("%s isn't on the reels", symbol)
+			}
+			symbols = append(symbols[:idx], symbols[idx+1:]...)
+		case idx < 0:
+			if weight == nil || three == nil {
+				return fmt.Errorf("a new symbol needs a weight and a three-of-a-kind payout")
+			}
+			sym := database.SlotSymbol{Emoji: symbol, Weight: *weight, Three: *three}
+			if two != nil {
+				sym.Two = *two
+			}
+			symbols = append(symbols, sym)
+		default:
+			if weight != nil {
+				symbols[idx].Weight = *weight
+			}
+			if three != nil {
+				symbols[idx].Three = *three
+			}
+			if two != nil {
+				symbols[idx].Two = *two
+			}
+		}
+	}
+
+	if err := validateSlotSymbols(symbols); err != nil {
+		return err
+	}
+	if rate := slotsReturnRate(symbols, commission); rate > 1 {
+		return fmt.Errorf("the machine would pay back %.1f%% of every bet", rate*100)
+	}
+	settings.SlotsSymbols = symbols
+	settings.SlotsCommission = commission
+	return nil
+}
+
+func buildCasinoSettingsEmbed(settings *database.CasinoSettings) *discordgo.MessageEmbed {
+	var roulette strings.Builder
+	for _, group := range rouletteBetGroups {
+		odds := rouletteOdds(settings.RoulettePayouts, group.Name)
+		roulette.WriteString(fmt.Sprintf("**%s**: %d:1 (house edge %.2f%%)\n", group.Label, odds, rouletteHouseEdge(group.Size, odds, settings.RouletteCommission)*100))
+	}
+	roulette.WriteString(fmt.Sprintf("Commission: %d%%", settings.RouletteCommission))
+
+	var slots strings.Builder
+	for _, sym := range settings.SlotsSymbols {
+		slots.WriteString(fmt.Sprintf("%s weight %d: three pays **x%d**, pair pays **x%d**\n", sym.Emoji, sym.Weight, sym.Three, sym.Two))
+	}
+	slots.WriteString(fmt.Sprintf("Commission: %d%%\nPays back %.2f%% (house edge %.2f%%)", settings.SlotsCommission,
+		slotsReturnRate(settings.SlotsSymbols, settings.SlotsCommission)*100, (1-slotsReturnRate(settings.SlotsSymbols, settings.SlotsCommission))*100))
+
+	return &discordgo.MessageEmbed{
+		Title: "Casino Settings",
+		Color: 0xe67e22,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "Roulette", Value: roulette.String()},
+			{Name: "Slots", Value: slots.String()},
+		},
+	}
+}
@@ -1,18 +1,13 @@
 package commands
 
-import (
-	"math/rand"
-	"time"
-)
-
 // Card represents a playing card.
 type Card struct {
 	Suit  string
 	Value string
 }
 
-// NewDeck creates a standard 52-card deck.
-func NewDeck() []Card {
+// NewOrderedDeck creates a standard 52-card deck in suit and rank order.
+func NewOrderedDeck() []Card {
 	suits := []string{"♠", "♥", "♦", "♣"}
 	values := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
 	deck := []Card{}
@@ -22,7 +17,12 @@ func NewDeck() []Card {
 			deck = append(deck, Card{Suit: s, Value: v})
 		}
 	}
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	return deck
+}
+
+// NewDeck creates a standard 52-card deck shuffled by DefaultShuffler.
+func NewDeck() []Card {
+	deck := NewOrderedDeck()
+	ShuffleCards(deck, DefaultShuffler)
 	return deck
 }
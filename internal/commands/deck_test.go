@@ -0,0 +1,25 @@
+package commands
+
+import "testing"
+
+func TestNewDeck(t *testing.T) {
+	ordered := NewOrderedDeck()
+	shuffled := NewDeck()
+	if len(ordered) != 52 || len(shuffled) != 52 {
+		t.Fatalf("got %d ordered and %d shuffled cards, want 52", len(ordered), len(shuffled))
+	}
+
+	seen := make(map[Card]bool)
+	for _, c := range ordered {
+		if seen[c] {
+			t.Errorf("duplicate card %v", c)
+		}
+		seen[c] = true
+	}
+	for _, c := range shuffled {
+		if !seen[c] {
+			t.Errorf("shuffled deck has unknown or repeated card %v", c)
+		}
+		delete(seen, c)
+	}
+}
@@ -0,0 +1,462 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var HangmanCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "hangman",
+		Description: "Cooperative Hangman for the whole channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "start",
+				Description: "Start a game in this channel",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "length",
+						Description: "Word length (random if left out)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "4 letters", Value: 4},
+							{Name: "5 letters", Value: 5},
+							{Name: "6 letters", Value: 6},
+							{Name: "7 letters", Value: 7},
+						},
+					},
+				},
+			},
+			{
+				Name:        "guess",
+				Description: "Guess a letter or the whole word",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "guess",
+						Description: "A single letter, or the whole word",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+}
+
+const (
+	hangmanMaxWrong = 6
+	// hangmanLetterReward is paid per revealed letter to whoever guessed it, if the channel wins.
+	hangmanLetterReward = 10
+	// hangmanSolveBonus is paid on top of the hidden letters to whoever guesses the whole word.
+	hangmanSolveBonus = 25
+	hangmanIdleTime   = 10 * time.Minute
+	hangmanAlphabet   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// hangmanStages are drawn for 0 through hangmanMaxWrong wrong guesses.
+var hangmanStages = []string{
+	" +---+\n |   |\n     |\n     |\n     |\n=======",
+	" +---+\n |   |\n O   |\n     |\n     |\n=======",
+	" +---+\n |   |\n O   |\n |   |\n     |\n=======",
+	" +---+\n |   |\n O   |\n/|   |\n     |\n=======",
+	" +---+\n |   |\n O   |\n/|\\  |\n     |\n=======",
+	" +---+\n |   |\n O   |\n/|\\  |\n/    |\n=======",
+	" +---+\n |   |\n O   |\n/|\\  |\n/ \\  |\n=======",
+}
+
+type HangmanGame struct {
+	ChannelID string
+	MessageID string
+	Word      string
+	Guessed   map[rune]bool
+	Wrong     []string       // Wrong letters and words, in order
+	Earned    map[string]int // userID -> reward owed if the channel wins
+	LastGuess string
+	Finished  bool
+	Result    string
+	Timer     *time.Timer
+}
+
+var (
+	// hangmanGames holds the running game for each channel.
+	hangmanGames = make(map[string]*HangmanGame)
+	hangmanMutex sync.Mutex
+)
+
+// maskHangmanWord shows guessed letters and underscores for the rest.
+func maskHangmanWord(word string, guessed map[rune]bool) string {
+	parts := make([]string, 0, len(word))
+	for _, r := range word {
+		if guessed[r] {
+			parts = append(parts, string(r))
+		} else {
+			parts = append(parts, "_")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func hangmanSolved(word string, guessed map[rune]bool) bool {
+	for _, r := range word {
+		if !guessed[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// guessHangmanLetter records a letter and returns how many positions it revealed. Call with hangmanMutex held.
+func guessHangmanLetter(g *HangmanGame, userID string, letter rune) int {
+	g.Guessed[letter] = true
+	revealed := strings.Count(g.Word, string(letter))
+	if revealed == 0 {
+		g.Wrong = append(g.Wrong, string(letter))
+		return 0
+	}
+	g.Earned[userID] += revealed * hangmanLetterReward
+	return revealed
+}
+
+// guessHangmanWord checks a full-word guess. A right guess reveals everything and pays the solver for the hidden letters; a wrong one costs a life. Call with hangmanMutex held.
+func guessHangmanWord(g *HangmanGame, userID, word string) bool {
+	if word != g.Word {
+		g.Wrong = append(g.Wrong, word)
+		return false
+	}
+	hidden := 0
+	for _, r := range g.Word {
+		if !g.Guessed[r] {
+			hidden++
+		}
+	}
+	for _, r := range g.Word {
+		g.Guessed[r] = true
+	}
+	g.Earned[userID] += hidden*hangmanLetterReward + hangmanSolveBonus
+	return true
+}
+
+func HandleHangmanCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	if len(data.Options) == 0 {
+		return
+	}
+
+	switch data.Options[0].Name {
+	case "start":
+		handleHangmanStart(s, i, data.Options[0].Options)
+	case "guess":
+		guess := strings.ToUpper(strings.TrimSpace(data.Options[0].Options[0].StringValue()))
+		hangmanGuess(s, i, guess, false)
+	}
+}
+
+func handleHangmanStart(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	var lengths []int
+	if len(options) > 0 {
+		lengths = []int{int(options[0].IntValue())}
+	} else {
+		lengths = wordleLengths
+	}
+	var available []int
+	for _, l := range lengths {
+		if wordleLengthAvailable(defaultWordleLang, l) {
+			available = append(available, l)
+		}
+	}
+	if len(available) == 0 {
+		respondError(s, i, "No words of that length are loaded.")
+		return
+	}
+
+	hangmanMutex.Lock()
+	if _, running := hangmanGames[i.ChannelID]; running {
+		hangmanMutex.Unlock()
+		respondError(s, i, "A Hangman game is already running in this channel.")
+		return
+	}
+	g := &HangmanGame{
+		ChannelID: i.ChannelID,
+		Word:      GetRandomWord(defaultWordleLang, available[DefaultShuffler.Intn(len(available))]),
+		Guessed:   make(map[rune]bool),
+		Earned:    make(map[string]int),
+	}
+	hangmanGames[i.ChannelID] = g
+	hangmanMutex.Unlock()
+	log.Printf("[HANGMAN START] Channel: %s | By: %s | Word: %s", i.ChannelID, i.Member.User.ID, g.Word)
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{buildHangmanEmbed(g)},
+			Components: createHangmanLetterMenus(g),
+		},
+	})
+	if err != nil {
+		removeHangmanGame(g)
+		return
+	}
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		removeHangmanGame(g)
+		return
+	}
+
+	hangmanMutex.Lock()
+	g.MessageID = msg.ID
+	resetHangmanTimer(s, g)
+	hangmanMutex.Unlock()
+}
+
+func removeHangmanGame(g *HangmanGame) {
+	hangmanMutex.Lock()
+	defer hangmanMutex.Unlock()
+	if hangmanGames[g.ChannelID] == g {
+		delete(hangmanGames, g.ChannelID)
+	}
+}
+
+// resetHangmanTimer ends the game after hangmanIdleTime without a guess. Call with hangmanMutex held.
+func resetHangmanTimer(s *discordgo.Session, g *HangmanGame) {
+	if g.Timer != nil {
+		g.Timer.Stop()
+	}
+	guesses := len(g.Guessed) + len(g.Wrong)
+	g.Timer = time.AfterFunc(hangmanIdleTime, func() {
+		hangmanMutex.Lock()
+		defer hangmanMutex.Unlock()
+		if g.Finished || len(g.Guessed)+len(g.Wrong) != guesses {
+			return
+		}
+		finishHangmanGame(g, false, fmt.Sprintf("Nobody guessed for a while. The word was **%s**.", g.Word))
+		embed := buildHangmanEmbed(g)
+		s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			Channel:    g.ChannelID,
+			ID:         g.MessageID,
+			Embed:      embed,
+			Components: &[]discordgo.MessageComponent{},
+		})
+	})
+}
+
+// hangmanGuess applies a guess from the slash command or the letter menus and updates the game message.
+func hangmanGuess(s *discordgo.Session, i *discordgo.InteractionCreate, guess string, fromMenu bool) {
+	userID := i.Member.User.ID
+
+	hangmanMutex.Lock()
+	g, exists := hangmanGames[i.ChannelID]
+	if !exists || g.Finished || (fromMenu && g.MessageID != i.Message.ID) {
+		hangmanMutex.Unlock()
+		respondError(s, i, "There's no Hangman game in this channel. Start one with `/hangman start`.")
+		return
+	}
+
+	runes := []rune(guess)
+	for _, r := range runes {
+		if !strings.ContainsRune(hangmanAlphabet, r) {
+			hangmanMutex.Unlock()
+			respondError(s, i, "Guesses can only use the letters A to Z.")
+			return
+		}
+	}
+
+	var note string
+	switch {
+	case len(runes) == 1:
+		if g.Guessed[runes[0]] {
+			hangmanMutex.Unlock()
+			respondError(s, i, fmt.Sprintf("**%s** was already guessed.", guess))
+			return
+		}
+		if n := guessHangmanLetter(g, userID, runes[0]); n > 0 {
+			note = fmt.Sprintf("✅ **%s** guessed **%s** (%d).", i.Member.User.Username, guess, n)
+		} else {
+			note = fmt.Sprintf("❌ **%s** guessed **%s**, which isn't in the word.", i.Member.User.Username, guess)
+		}
+	case len(runes) == len([]rune(g.Word)):
+		for _, w := range g.Wrong {
+			if w == guess {
+				hangmanMutex.Unlock()
+				respondError(s, i, fmt.Sprintf("**%s** was already guessed.", guess))
+				return
+			}
+		}
+		// Lives are shared, so only real words may cost one
+		if guess != g.Word && !IsValidWord(defaultWordleLang, guess) {
+			hangmanMutex.Unlock()
+			respondError(s, i, fmt.Sprintf("**%s** isn't in the word list.", guess))
+			return
+		}
+		if guessHangmanWord(g, userID, guess) {
+			note = fmt.Sprintf("🎉 **%s** solved it!", i.Member.User.Username)
+		} else {
+			note = fmt.Sprintf("❌ **%s** guessed **%s**, which isn't the word.", i.Member.User.Username, guess)
+		}
+	default:
+		hangmanMutex.Unlock()
+		respondError(s, i, fmt.Sprintf("Guess one letter or a %d-letter word.", len([]rune(g.Word))))
+		return
+	}
+	g.LastGuess = note
+
+	switch {
+	case hangmanSolved(g.Word, g.Guessed):
+		finishHangmanGame(g, true, fmt.Sprintf("The word was **%s**!", g.Word))
+	case len(g.Wrong) >= hangmanMaxWrong:
+		finishHangmanGame(g, false, fmt.Sprintf("Out of guesses! The word was **%s**.", g.Word))
+	default:
+		resetHangmanTimer(s, g)
+	}
+
+	embed := buildHangmanEmbed(g)
+	components := createHangmanLetterMenus(g)
+	messageID := g.MessageID
+	hangmanMutex.Unlock()
+
+	if fromMenu {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embed},
+				Components: components,
+			},
+		})
+		return
+	}
+
+	respondSuccess(s, i, note)
+	if messageID != "" {
+		s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			Channel:    i.ChannelID,
+			ID:         messageID,
+			Embed:      embed,
+			Components: &components,
+		})
+	}
+}
+
+// finishHangmanGame ends the game and, if the channel won, pays everyone what their letters earned. Call with hangmanMutex held.
+func finishHangmanGame(g *HangmanGame, won bool, result string) {
+	g.Finished = true
+	g.Result = result
+	if g.Timer != nil {
+		g.Timer.Stop()
+	}
+	if hangmanGames[g.ChannelID] == g {
+		delete(hangmanGames, g.ChannelID)
+	}
+
+	if !won {
+		g.Earned = map[string]int{}
+		log.Printf("[HANGMAN LOST] Channel: %s | Word: %s", g.ChannelID, g.Word)
+		return
+	}
+	for userID, amount := range g.Earned {
+		if err := DB.AddBalance(userID, amount); err != nil {
+			log.Printf("[HANGMAN] Failed to pay %s $%d: %v", userID, amount, err)
+		}
+	}
+	log.Printf("[HANGMAN WON] Channel: %s | Word: %s | Players paid: %d", g.ChannelID, g.Word, len(g.Earned))
+}
+
+func buildHangmanEmbed(g *HangmanGame) *discordgo.MessageEmbed {
+	wrong := "None"
+	if len(g.Wrong) > 0 {
+		wrong = strings.Join(g.Wrong, ", ")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "Hangman",
+		Description: fmt.Sprintf("```\n%s\n```\n**`%s`**", hangmanStages[min(len(g.Wrong), hangmanMaxWrong)], maskHangmanWord(g.Word, g.Guessed)),
+		Color:       0x3498db,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: fmt.Sprintf("Misses (%d/%d)", len(g.Wrong), hangmanMaxWrong), Value: wrong},
+		},
+		Footer: &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Pick letters below or use /hangman guess. Each letter you find pays $%d if the channel wins.", hangmanLetterReward)},
+	}
+
+	if g.LastGuess != "" && !g.Finished {
+		embed.Description += "\n\n" + g.LastGuess
+	}
+
+	if len(g.Earned) > 0 {
+		ids := make([]string, 0, len(g.Earned))
+		for userID := range g.Earned {
+			ids = append(ids, userID)
+		}
+		sort.Slice(ids, func(a, b int) bool {
+			if g.Earned[ids[a]] != g.Earned[ids[b]] {
+				return g.Earned[ids[a]] > g.Earned[ids[b]]
+			}
+			return ids[a] < ids[b]
+		})
+		var sb strings.Builder
+		for _, userID := range ids {
+			sb.WriteString(fmt.Sprintf("<@%s>: $%d\n", userID, g.Earned[userID]))
+		}
+		name := "Contributors"
+		if g.Finished {
+			name = "Paid Out"
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: name, Value: sb.String()})
+	}
+
+	if g.Finished {
+		embed.Description += "\n\n" + g.Result
+		embed.Footer = nil
+		if hangmanSolved(g.Word, g.Guessed) {
+			embed.Color = 0x00FF00
+		} else {
+			embed.Color = 0xe74c3c
+		}
+	}
+	return embed
+}
+
+// createHangmanLetterMenus offers the unguessed letters in two select menus, since a menu holds at most 25 options.
+func createHangmanLetterMenus(g *HangmanGame) []discordgo.MessageComponent {
+	if g.Finished {
+		return []discordgo.MessageComponent{}
+	}
+
+	halves := []string{hangmanAlphabet[:13], hangmanAlphabet[13:]}
+	var rows []discordgo.MessageComponent
+	for idx, letters := range halves {
+		var options []discordgo.SelectMenuOption
+		for _, r := range letters {
+			if !g.Guessed[r] {
+				options = append(options, discordgo.SelectMenuOption{Label: string(r), Value: string(r)})
+			}
+		}
+		if len(options) == 0 {
+			continue
+		}
+		rows = append(rows, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:    discordgo.StringSelectMenu,
+					CustomID:    fmt.Sprintf("hangman_letter:%d", idx),
+					Placeholder: fmt.Sprintf("Guess a letter (%c-%c)", letters[0], letters[len(letters)-1]),
+					Options:     options,
+				},
+			},
+		})
+	}
+	return rows
+}
+
+func HandleHangmanComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.MessageComponentData()
+	if !strings.HasPrefix(data.CustomID, "hangman_letter:") || len(data.Values) == 0 {
+		return
+	}
+	hangmanGuess(s, i, data.Values[0], true)
+}
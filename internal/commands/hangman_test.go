@@ -0,0 +1,74 @@
+package commands
+
+import "testing"
+
+func newTestHangman(word string) *HangmanGame {
+	return &HangmanGame{Word: word, Guessed: make(map[rune]bool), Earned: make(map[string]int)}
+}
+
+func TestMaskHangmanWord(t *testing.T) {
+	guessed := map[rune]bool{'A': true, 'E': true}
+	if got := maskHangmanWord("APPLE", guessed); got != "A _ _ _ E" {
+		t.Errorf("got %q", got)
+	}
+	if hangmanSolved("APPLE", guessed) {
+		t.Error("APPLE shouldn't be solved yet")
+	}
+	guessed['P'], guessed['L'] = true, true
+	if !hangmanSolved("APPLE", guessed) {
+		t.Error("APPLE should be solved")
+	}
+}
+
+func TestHangmanRewards(t *testing.T) {
+	g := newTestHangman("APPLE")
+
+	if n := guessHangmanLetter(g, "alice", 'P'); n != 2 {
+		t.Errorf("P revealed %d letters, want 2", n)
+	}
+	if n := guessHangmanLetter(g, "bob", 'Z'); n != 0 {
+		t.Errorf("Z revealed %d letters, want 0", n)
+	}
+	if len(g.Wrong) != 1 || g.Wrong[0] != "Z" {
+		t.Errorf("wrong guesses = %v", g.Wrong)
+	}
+
+	if guessHangmanWord(g, "bob", "AMPLE") {
+		t.Error("AMPLE shouldn't solve APPLE")
+	}
+	if !guessHangmanWord(g, "carol", "APPLE") {
+		t.Error("APPLE should solve APPLE")
+	}
+
+	want := map[string]int{
+		"alice": 2 * hangmanLetterReward,
+		"carol": 3*hangmanLetterReward + hangmanSolveBonus, // A, L and E were still hidden
+	}
+	for userID, amount := range want {
+		if g.Earned[userID] != amount {
+			t.Errorf("%s earned %d, want %d", userID, g.Earned[userID], amount)
+		}
+	}
+	if _, ok := g.Earned["bob"]; ok {
+		t.Error("bob found nothing and shouldn't be owed anything")
+	}
+	if len(g.Wrong) != 2 || !hangmanSolved(g.Word, g.Guessed) {
+		t.Errorf("after solving: wrong %v, solved %v", g.Wrong, hangmanSolved(g.Word, g.Guessed))
+	}
+}
+
+func TestHangmanLetterMenus(t *testing.T) {
+	g := newTestHangman("APPLE")
+	if rows := createHangmanLetterMenus(g); len(rows) != 2 {
+		t.Fatalf("got %d menus, want 2", len(rows))
+	}
+	for _, r := range hangmanAlphabet[:13] {
+		g.Guessed[r] = true
+	}
+	if rows := createHangmanLetterMenus(g); len(rows) != 1 {
+		t.Errorf("got %d menus once A-M are guessed, want 1", len(rows))
+	}
+	if len(hangmanStages) != hangmanMaxWrong+1 {
+		t.Errorf("%d gallows stages for %d misses", len(hangmanStages), hangmanMaxWrong)
+	}
+}
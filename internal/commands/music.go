@@ -2,9 +2,12 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"soosa/internal/player"
+	"soosa/internal/subsonic"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -16,13 +19,55 @@ var PlayerManager *player.Manager
 var MusicCommands = []*discordgo.ApplicationCommand{
 	{
 		Name:        "play",
-		Description: "Search and play a song from the music server",
+		Description: "Play a song, album, artist or playlist from the music server, a web link or a local file",
 		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "query",
+				Description:  "Song name or search query",
+				Required:     false,
+				Autocomplete: true,
+			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "query",
-				Description: "Song name or search query",
-				Required:    true,
+				Name:        "album",
+				Description: "Queue a whole album",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "artist",
+				Description: "Queue songs by an artist",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tracks",
+				Description: "With artist: their top songs or everything (default top)",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Top songs", Value: "top"},
+					{Name: "All songs", Value: "all"},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "playlist",
+				Description: "Queue a playlist by name",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "url",
+				Description: "Play a direct http(s) link to an audio file or stream",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "file",
+				Description:  "Play a file from the bot's local music folder",
+				Required:     false,
+				Autocomplete: true,
 			},
 		},
 	},
@@ -31,16 +76,92 @@ var MusicCommands = []*discordgo.ApplicationCommand{
 		Description: "Search for songs on the music server",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "query",
-				Description: "Search query",
-				Required:    true,
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "query",
+				Description:  "Search query",
+				Required:     true,
+				Autocomplete: true,
 			},
 		},
 	},
 	{
 		Name:        "queue",
-		Description: "Show the current music queue",
+		Description: "Show or rearrange the music queue",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "view",
+				Description: "Show the upcoming songs",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "page",
+						Description: "Page to show",
+						Required:    false,
+						MinValue:    &[]float64{1}[0],
+					},
+				},
+			},
+			{
+				Name:        "remove",
+				Description: "Remove a song from the queue",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "pos",
+						Description: "Queue position of the song",
+						Required:    true,
+						MinValue:    &[]float64{1}[0],
+					},
+				},
+			},
+			{
+				Name:        "move",
+				Description: "Move a song to another position in the queue",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "from",
+						Description: "Current queue position of the song",
+						Required:    true,
+						MinValue:    &[]float64{1}[0],
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "to",
+						Description: "New queue position",
+						Required:    true,
+						MinValue:    &[]float64{1}[0],
+					},
+				},
+			},
+			{
+				Name:        "shuffle",
+				Description: "Shuffle the upcoming songs",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "clear",
+				Description: "Remove every upcoming song, keeping the current one",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "jump",
+				Description: "Skip straight to a song in the queue",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "pos",
+						Description: "Queue position of the song",
+						Required:    true,
+						MinValue:    &[]float64{1}[0],
+					},
+				},
+			},
+		},
 	},
 	{
 		Name:        "skip",
@@ -62,6 +183,36 @@ var MusicCommands = []*discordgo.ApplicationCommand{
 		Name:        "resume",
 		Description: "Resume playback",
 	},
+	{
+		Name:        "seek",
+		Description: "Jump to a position in the current song",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "position",
+				Description: "A time like 1:23, or +30s / -15s to move from where it is now",
+				Required:    true,
+			},
+		},
+	},
+	{
+		Name:        "loop",
+		Description: "Show or change the loop mode, or let autoplay keep the music going",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "mode",
+				Description: "What to repeat",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Off", Value: player.LoopOff.String()},
+					{Name: "Track", Value: player.LoopTrack.String()},
+					{Name: "Queue", Value: player.LoopQueue.String()},
+					{Name: "Autoplay", Value: player.LoopAutoplay.String()},
+				},
+			},
+		},
+	},
 	{
 		Name:        "volume",
 		Description: "Show or set playback volume",
@@ -88,7 +239,7 @@ func HandleMusicCommand(s *discordgo.Session, i *discordgo.InteractionCreate, da
 	case "search":
 		handleSearch(s, i, data)
 	case "queue":
-		handleQueue(s, i)
+		handleQueue(s, i, data)
 	case "skip":
 		handleSkip(s, i)
 	case "stop":
@@ -101,6 +252,10 @@ func HandleMusicCommand(s *discordgo.Session, i *discordgo.InteractionCreate, da
 		handleResume(s, i)
 	case "volume":
 		handleVolume(s, i, data)
+	case "loop":
+		handleLoop(s, i, data)
+	case "seek":
+		handleSeek(s, i, data)
 	}
 }
 
@@ -116,6 +271,14 @@ func HandleMusicComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 		handleStop(s, i) // Use existing stop handler
 	case "music_next":
 		handleSkip(s, i) // Next is just skip
+	case "music_loop":
+		handleLoopToggle(s, i)
+	default:
+		if strings.HasPrefix(data.CustomID, "music_queue_page:") {
+			handleQueuePage(s, i)
+		} else if strings.HasPrefix(data.CustomID, "music_search_") {
+			handleSearchComponent(s, i)
+		}
 	}
 }
 
@@ -153,10 +316,97 @@ func findUserVoiceChannel(s *discordgo.Session, guildID, userID string) string {
 	return ""
 }
 
+// musicControlButtons builds the player controls shown under the Now Playing embed.
+func musicControlButtons(mode player.LoopMode) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Prev",
+					Emoji:    &discordgo.ComponentEmoji{Name: "⏮️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: "music_prev",
+				},
+				discordgo.Button{
+					Label:    "Pause/Plau",
+					Emoji:    &discordgo.ComponentEmoji{Name: "⏯️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: "music_pause",
+				},
+				discordgo.Button{
+					Label:    "Stop",
+					Emoji:    &discordgo.ComponentEmoji{Name: "⏹️"},
+					Style:    discordgo.DangerButton,
+					CustomID: "music_stop",
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Emoji:    &discordgo.ComponentEmoji{Name: "⏭️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: "music_next",
+				},
+				discordgo.Button{
+					Label:    fmt.Sprintf("Loop: %s", mode),
+					Emoji:    &discordgo.ComponentEmoji{Name: "🔁"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: "music_loop",
+				},
+			},
+		},
+	}
+}
+
+// loopModeLabel returns how a loop mode is shown on embeds and buttons.
+func loopModeLabel(mode player.LoopMode) string {
+	switch mode {
+	case player.LoopTrack:
+		return "🔂 Track"
+	case player.LoopQueue:
+		return "🔁 Queue"
+	case player.LoopAutoplay:
+		return "📻 Autoplay"
+	default:
+		return "Off"
+	}
+}
+
+func loopField(mode player.LoopMode) *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{Name: "Loop", Value: loopModeLabel(mode), Inline: true}
+}
+
+// setLoopField replaces the Loop field on an embed, adding one if it doesn't have it yet.
+func setLoopField(embed *discordgo.MessageEmbed, mode player.LoopMode) {
+	for _, field := range embed.Fields {
+		if field.Name == "Loop" {
+			field.Value = loopModeLabel(mode)
+			return
+		}
+	}
+	embed.Fields = append(embed.Fields, loopField(mode))
+}
+
 // --- Command Handlers ---
 
 func handlePlay(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
-	query := data.Options[0].StringValue()
+	opts := make(map[string]string)
+	for _, opt := range data.Options {
+		opts[opt.Name] = strings.TrimSpace(opt.StringValue())
+	}
+	sources := 0
+	for _, name := range []string{"query", "album", "artist", "playlist", "url", "file"} {
+		if opts[name] != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		respond(s, i, "❌ Give exactly one of query, album, artist, playlist, url or file.")
+		return
+	}
+	query := opts["query"]
+	if (opts["url"] != "" || opts["file"] != "") && !hasPermission(i.Member.User.ID, "play.external") {
+		respond(s, i, "🚫 You do not have permission to play links or local files.")
+		return
+	}
 
 	// Find user's voice channel
 	channelID := findUserVoiceChannel(s, i.GuildID, i.Member.User.ID)
@@ -170,8 +420,71 @@ func handlePlay(s *discordgo.Session, i *discordgo.InteractionCreate, data disco
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
 	})
 
-	// Search for the song
+	if opts["url"] != "" || opts["file"] != "" {
+		// Links and local files are probed with ffprobe rather than looked up on the server
+		var song *subsonic.Song
+		var err error
+		if opts["url"] != "" {
+			song, err = player.NewURLSong(opts["url"])
+		} else {
+			song, err = player.NewLocalSong(PlayerManager.Config.MusicDir, opts["file"])
+		}
+		if err != nil {
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: strPtr(fmt.Sprintf("❌ Couldn't play that: %v.", err)),
+			})
+			return
+		}
+		length := player.FormatDuration(song.Duration)
+		if song.IsLive() {
+			length = "live"
+		}
+		playSongs(s, i, channelID, []*subsonic.Song{song}, fmt.Sprintf("🎵 Added to queue: **%s** — %s (%s)",
+			song.Title, song.Artist, length))
+		return
+	}
+
 	subClient := PlayerManager.GetSubsonicClient()
+
+	// Autocomplete submits Subsonic IDs instead of the text that was typed
+	suggestion, suggested := lookupMusicSuggestion(query)
+	if query == "" || suggestion.Kind == "album" || suggestion.Kind == "artist" {
+		// Albums, artists and playlists are queued in full
+		var songs []*subsonic.Song
+		var source string
+		var err error
+		switch {
+		case suggestion.Kind == "album":
+			songs, source, err = loadAlbum(subClient, query)
+		case suggestion.Kind == "artist":
+			songs, source, err = loadArtist(subClient, query, opts["tracks"] == "all")
+		case opts["album"] != "":
+			songs, source, err = resolveAlbum(subClient, opts["album"])
+		case opts["artist"] != "":
+			songs, source, err = resolveArtist(subClient, opts["artist"], opts["tracks"] == "all")
+		default:
+			songs, source, err = resolvePlaylist(subClient, opts["playlist"])
+		}
+		if err != nil {
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: strPtr(fmt.Sprintf("❌ Nothing to play: %v.", err)),
+			})
+			return
+		}
+		summary := fmt.Sprintf("📀 Queued **%d** songs from %s (%s)", len(songs), source, player.FormatDuration(totalDuration(songs)))
+		playSongs(s, i, channelID, songs, summary)
+		return
+	}
+
+	if suggested || looksLikeSubsonicID(query) {
+		if song, err := subClient.GetSong(query); err == nil && song != nil {
+			playSongs(s, i, channelID, []*subsonic.Song{song}, fmt.Sprintf("🎵 Added to queue: **%s** — %s (%s)",
+				song.Title, song.Artist, player.FormatDuration(song.Duration)))
+			return
+		}
+	}
+
+	// Search for the song
 	results, err := subClient.Search(query, 1)
 	if err != nil {
 		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
@@ -188,21 +501,28 @@ func handlePlay(s *discordgo.Session, i *discordgo.InteractionCreate, data disco
 	}
 
 	song := &results.Songs[0]
+	playSongs(s, i, channelID, []*subsonic.Song{song}, fmt.Sprintf("🎵 Added to queue: **%s** — %s (%s)",
+		song.Title, song.Artist, player.FormatDuration(song.Duration)))
+}
+
+// playSongs queues songs for a deferred interaction. If something is already playing it replies with summary, otherwise it joins voice and replies with the Now Playing controls for the first song.
+func playSongs(s *discordgo.Session, i *discordgo.InteractionCreate, channelID string, songs []*subsonic.Song, summary string) {
+	subClient := PlayerManager.GetSubsonicClient()
 	p := PlayerManager.GetOrCreatePlayer(i.GuildID)
+	song := songs[0]
 
 	// If already playing, just enqueue
 	if p.IsPlaying {
-		p.Enqueue(song)
+		p.EnqueueAll(songs)
 		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: strPtr(fmt.Sprintf("🎵 Added to queue: **%s** — %s (%s)",
-				song.Title, song.Artist, player.FormatDuration(song.Duration))),
+			Content: strPtr(summary),
 		})
 		return
 	}
 
 	// Otherwise enqueue and start playing
-	p.Enqueue(song)
-	err = p.Start(channelID)
+	p.EnqueueAll(songs)
+	err := p.Start(channelID)
 	if err != nil {
 		PlayerManager.Remove(i.GuildID)
 		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
@@ -211,132 +531,146 @@ func handlePlay(s *discordgo.Session, i *discordgo.InteractionCreate, data disco
 		return
 	}
 
-	embed := &discordgo.MessageEmbed{
-		Title:       "🎶 Now Playing",
-		Description: fmt.Sprintf("**%s**\n%s", song.Title, song.Artist),
-		Color:       0x1DB954,
-		Fields: []*discordgo.MessageEmbedField{
-			{Name: "Album", Value: song.Album, Inline: true},
-			{Name: "Duration", Value: player.FormatDuration(song.Duration), Inline: true},
-		},
-	}
-	if song.CoverArt != "" {
-		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
-			URL: subClient.GetCoverArtURL(song.CoverArt, 300),
+	// The player fills in NowPlaying once it dequeues, so show the first song until then
+	st := p.Snapshot()
+	embed := buildNowPlayingEmbed(st)
+	if embed == nil || st.NowPlaying != song {
+		embed = &discordgo.MessageEmbed{
+			Title:       "🎶 Now Playing",
+			Description: fmt.Sprintf("**%s**\n%s", song.Title, song.Artist),
+			Color:       0x1DB954,
+			Fields:      []*discordgo.MessageEmbedField{loopField(st.Loop)},
+		}
+		if song.CoverArt != "" {
+			embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
+				URL: subClient.GetCoverArtURL(song.CoverArt, 300),
+			}
 		}
 	}
+	components := musicControlButtons(st.Loop)
 
-	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-		Name: "Requested by", Value: i.Member.User.Mention(), Inline: true,
-	})
-
-	// Add buttons
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "Prev",
-					Emoji:    &discordgo.ComponentEmoji{Name: "⏮️"},
-					Style:    discordgo.SecondaryButton,
-					CustomID: "music_prev",
-				},
-				discordgo.Button{
-					Label:    "Pause/Plau",
-					Emoji:    &discordgo.ComponentEmoji{Name: "⏯️"},
-					Style:    discordgo.SecondaryButton,
-					CustomID: "music_pause",
-				},
-				discordgo.Button{
-					Label:    "Stop",
-					Emoji:    &discordgo.ComponentEmoji{Name: "⏹️"},
-					Style:    discordgo.DangerButton,
-					CustomID: "music_stop",
-				},
-				discordgo.Button{
-					Label:    "Next",
-					Emoji:    &discordgo.ComponentEmoji{Name: "⏭️"},
-					Style:    discordgo.SecondaryButton,
-					CustomID: "music_next",
-				},
-			},
-		},
-	}
-
-	msg, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+	edit := &discordgo.WebhookEdit{
 		Embeds:     &[]*discordgo.MessageEmbed{embed},
 		Components: &components,
-	})
+	}
+	if len(songs) > 1 {
+		edit.Content = strPtr(summary)
+	}
+	msg, err := s.InteractionResponseEdit(i.Interaction, edit)
 
 	if err == nil && msg != nil {
 		p.SetLastMessage(msg.ID, msg.ChannelID)
+		// Catch up in case the song started before the message existed
+		HandlePlayerEvent(s, p, player.EventTrackChange)
 	}
 }
 
-func handleSearch(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
-	query := data.Options[0].StringValue()
+func handleQueue(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	p := PlayerManager.GetPlayer(i.GuildID)
+	if p == nil || !p.IsPlaying {
+		respond(s, i, "📭 Nothing is playing right now.")
+		return
+	}
 
-	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-	})
+	sub := "view"
+	var options []*discordgo.ApplicationCommandInteractionDataOption
+	if len(data.Options) > 0 {
+		sub = data.Options[0].Name
+		options = data.Options[0].Options
+	}
+	args := make(map[string]int)
+	for _, opt := range options {
+		args[opt.Name] = int(opt.IntValue())
+	}
 
-	subClient := PlayerManager.GetSubsonicClient()
-	results, err := subClient.Search(query, 5)
-	if err != nil {
-		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: strPtr(fmt.Sprintf("❌ Search failed: %v", err)),
-		})
+	if sub != "view" && !hasPermission(i.Member.User.ID, "queue.edit") {
+		respond(s, i, "🚫 You do not have permission to edit the queue.")
 		return
 	}
 
-	if len(results.Songs) == 0 {
-		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: strPtr(fmt.Sprintf("❌ No results found for: **%s**", query)),
+	switch sub {
+	case "view":
+		page := 0
+		if n, ok := args["page"]; ok {
+			page = n - 1
+		}
+		embed, components := buildQueuePage(p, page)
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{embed},
+				Components: components,
+			},
 		})
-		return
+	case "remove":
+		song, err := p.RemoveAt(args["pos"] - 1)
+		if err != nil {
+			respond(s, i, fmt.Sprintf("❌ There is no song at position %d.", args["pos"]))
+			return
+		}
+		respond(s, i, fmt.Sprintf("🗑️ Removed **%s** — %s from the queue.", song.Title, song.Artist))
+	case "move":
+		song, err := p.Move(args["from"]-1, args["to"]-1)
+		if err != nil {
+			respond(s, i, fmt.Sprintf("❌ The queue only has %d songs.", len(p.GetQueue())))
+			return
+		}
+		respond(s, i, fmt.Sprintf("↕️ Moved **%s** — %s to position %d.", song.Title, song.Artist, args["to"]))
+	case "shuffle":
+		if n := p.Shuffle(); n < 2 {
+			respond(s, i, "❌ There is nothing to shuffle.")
+			return
+		}
+		respond(s, i, "🔀 Shuffled the queue.")
+	case "clear":
+		n := p.ClearQueue()
+		respond(s, i, fmt.Sprintf("🧹 Cleared %d songs from the queue.", n))
+	case "jump":
+		song, err := p.JumpTo(args["pos"] - 1)
+		if err != nil {
+			respond(s, i, fmt.Sprintf("❌ There is no song at position %d.", args["pos"]))
+			return
+		}
+		respond(s, i, fmt.Sprintf("⏩ Jumping to **%s** — %s.", song.Title, song.Artist))
 	}
+}
 
-	var lines []string
-	for idx, song := range results.Songs {
-		lines = append(lines, fmt.Sprintf("`%d.` **%s** — %s (%s)",
-			idx+1, song.Title, song.Artist, player.FormatDuration(song.Duration)))
-	}
+const queuePageSize = 10
 
-	embed := &discordgo.MessageEmbed{
-		Title:       fmt.Sprintf("🔎 Search Results for \"%s\"", query),
-		Description: strings.Join(lines, "\n"),
-		Color:       0x5865F2,
-		Footer:      &discordgo.MessageEmbedFooter{Text: "Use /play <song name> to play a result"},
+// queuePageCount returns how many pages a queue of n songs takes, never less than one.
+func queuePageCount(n int) int {
+	if n == 0 {
+		return 1
 	}
-
-	s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Embeds: &[]*discordgo.MessageEmbed{embed},
-	})
+	return (n + queuePageSize - 1) / queuePageSize
 }
 
-func handleQueue(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	p := PlayerManager.GetPlayer(i.GuildID)
-	if p == nil || !p.IsPlaying {
-		respond(s, i, "📭 Nothing is playing right now.")
-		return
+// buildQueuePage renders one page of the queue, clamping the page into range, along with its navigation buttons.
+func buildQueuePage(p *player.Player, page int) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	st := p.Snapshot()
+	queue := st.Queue
+	pages := queuePageCount(len(queue))
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
 	}
-
-	queue := p.GetQueue()
 
 	var desc strings.Builder
-	if p.NowPlaying != nil {
+	if song := st.NowPlaying; song != nil {
 		desc.WriteString(fmt.Sprintf("**Now Playing:**\n🎵 **%s** — %s (%s)\n\n",
-			p.NowPlaying.Title, p.NowPlaying.Artist, player.FormatDuration(p.NowPlaying.Duration)))
+			song.Title, song.Artist, player.FormatDuration(song.Duration)))
 	}
 
 	if len(queue) == 0 {
 		desc.WriteString("Queue is empty.")
 	} else {
 		desc.WriteString("**Up Next:**\n")
-		for idx, song := range queue {
-			if idx >= 10 {
-				desc.WriteString(fmt.Sprintf("\n...and %d more", len(queue)-10))
-				break
-			}
+		start := page * queuePageSize
+		end := min(start+queuePageSize, len(queue))
+		for idx := start; idx < end; idx++ {
+			song := queue[idx]
 			desc.WriteString(fmt.Sprintf("`%d.` **%s** — %s (%s)\n",
 				idx+1, song.Title, song.Artist, player.FormatDuration(song.Duration)))
 		}
@@ -346,18 +680,79 @@ func handleQueue(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		Title:       "📋 Queue",
 		Description: desc.String(),
 		Color:       0x5865F2,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Page %d/%d • %d songs • %s", page+1, pages, len(queue), player.FormatDuration(totalDuration(queue))),
+		},
+	}
+
+	if pages == 1 {
+		return embed, []discordgo.MessageComponent{}
 	}
-	respondEmbed(s, i, embed)
+	components := []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Prev",
+					Emoji:    &discordgo.ComponentEmoji{Name: "◀️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("music_queue_page:%d", page-1),
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    "Next",
+					Emoji:    &discordgo.ComponentEmoji{Name: "▶️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("music_queue_page:%d", page+1),
+					Disabled: page >= pages-1,
+				},
+			},
+		},
+	}
+	return embed, components
 }
 
-func handleSkip(s *discordgo.Session, i *discordgo.InteractionCreate) {
+func handleQueuePage(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var page int
+	if _, err := fmt.Sscanf(i.MessageComponentData().CustomID, "music_queue_page:%d", &page); err != nil {
+		return
+	}
+
 	p := PlayerManager.GetPlayer(i.GuildID)
 	if p == nil || !p.IsPlaying {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    "📭 Nothing is playing right now.",
+				Embeds:     []*discordgo.MessageEmbed{},
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	embed, components := buildQueuePage(p, page)
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	})
+}
+
+func handleSkip(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	p := PlayerManager.GetPlayer(i.GuildID)
+	if p == nil {
+		respond(s, i, "❌ Nothing is playing right now.")
+		return
+	}
+	st := p.Snapshot()
+	if !st.IsPlaying {
 		respond(s, i, "❌ Nothing is playing right now.")
 		return
 	}
 
-	skipped := p.NowPlaying
+	skipped := st.NowPlaying
 	p.Skip()
 
 	if skipped != nil {
@@ -412,35 +807,31 @@ func handleStop(s *discordgo.Session, i *discordgo.InteractionCreate) {
 
 func handleNowPlaying(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	p := PlayerManager.GetPlayer(i.GuildID)
-	if p == nil || !p.IsPlaying || p.NowPlaying == nil {
+	if p == nil {
 		respond(s, i, "📭 Nothing is playing right now.")
 		return
 	}
-
-	song := p.NowPlaying
-	subClient := PlayerManager.GetSubsonicClient()
-
-	embed := &discordgo.MessageEmbed{
-		Title:       "🎶 Now Playing",
-		Description: fmt.Sprintf("**%s**\n%s", song.Title, song.Artist),
-		Color:       0x1DB954,
-		Fields: []*discordgo.MessageEmbedField{
-			{Name: "Album", Value: song.Album, Inline: true},
-			{Name: "Duration", Value: player.FormatDuration(song.Duration), Inline: true},
-		},
-	}
-	if song.CoverArt != "" {
-		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
-			URL: subClient.GetCoverArtURL(song.CoverArt, 300),
-		}
+	st := p.Snapshot()
+	embed := buildNowPlayingEmbed(st)
+	if !st.IsPlaying || embed == nil {
+		respond(s, i, "📭 Nothing is playing right now.")
+		return
 	}
-	if song.Year > 0 {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name: "Year", Value: fmt.Sprintf("%d", song.Year), Inline: true,
-		})
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: musicControlButtons(st.Loop),
+		},
+	})
+	if err != nil {
+		return
 	}
 
-	respondEmbed(s, i, embed)
+	// Live updates move to the newest Now Playing message
+	if msg, err := s.InteractionResponse(i.Interaction); err == nil {
+		p.SetLastMessage(msg.ID, msg.ChannelID)
+	}
 }
 
 func handlePause(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -476,7 +867,7 @@ func handlePauseResumeToggle(s *discordgo.Session, i *discordgo.InteractionCreat
 		return
 	}
 
-	if p.IsPaused {
+	if p.Snapshot().IsPaused {
 		p.Resume()
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
@@ -502,7 +893,7 @@ func handleVolume(s *discordgo.Session, i *discordgo.InteractionCreate, data dis
 
 	if len(data.Options) == 0 {
 		// Show current volume
-		currentVol := int(float64(p.Volume) / 256.0 * 100.0)
+		currentVol := volumePercent(p.Snapshot().Volume)
 		respond(s, i, fmt.Sprintf("🔊 Current volume is **%d%%**.", currentVol))
 		return
 	}
@@ -516,6 +907,172 @@ func handleVolume(s *discordgo.Session, i *discordgo.InteractionCreate, data dis
 	respond(s, i, fmt.Sprintf("🔊 Volume set to **%d%%**.", level))
 }
 
+func handleLoop(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	p := PlayerManager.GetPlayer(i.GuildID)
+	if p == nil || !p.IsPlaying {
+		respond(s, i, "❌ Nothing is playing right now.")
+		return
+	}
+
+	if len(data.Options) == 0 {
+		respond(s, i, fmt.Sprintf("🔁 Loop mode is **%s**.", loopModeLabel(p.GetLoop())))
+		return
+	}
+
+	mode, ok := player.ParseLoopMode(data.Options[0].StringValue())
+	if !ok {
+		respond(s, i, "❌ Unknown loop mode.")
+		return
+	}
+	p.SetLoop(mode)
+	respond(s, i, fmt.Sprintf("🔁 Loop mode set to **%s**.", loopModeLabel(mode)))
+}
+
+// handleLoopToggle cycles the loop mode from the Now Playing buttons and updates the message to show it.
+func handleLoopToggle(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Components skip the command permission check, so the button has to make its own
+	if !hasPermission(i.Member.User.ID, "loop") {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "🚫 You do not have permission to change the loop mode.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	p := PlayerManager.GetPlayer(i.GuildID)
+	if p == nil || !p.IsPlaying {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "❌ Nothing is playing.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	mode := p.CycleLoop()
+	var embeds []*discordgo.MessageEmbed
+	if i.Message != nil && len(i.Message.Embeds) > 0 {
+		embed := *i.Message.Embeds[0]
+		embed.Fields = make([]*discordgo.MessageEmbedField, len(i.Message.Embeds[0].Fields))
+		for idx, field := range i.Message.Embeds[0].Fields {
+			copied := *field
+			embed.Fields[idx] = &copied
+		}
+		setLoopField(&embed, mode)
+		embeds = []*discordgo.MessageEmbed{&embed}
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     embeds,
+			Components: musicControlButtons(mode),
+		},
+	})
+}
+
+func handleSeek(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	p := PlayerManager.GetPlayer(i.GuildID)
+	if p == nil {
+		respond(s, i, "❌ Nothing is playing right now.")
+		return
+	}
+	st := p.Snapshot()
+	song := st.NowPlaying
+	if !st.IsPlaying || song == nil {
+		respond(s, i, "❌ Nothing is playing right now.")
+		return
+	}
+	if song.IsLive() {
+		respond(s, i, "❌ You can't seek in a live radio stream.")
+		return
+	}
+
+	target, err := parseSeekPosition(data.Options[0].StringValue(), st.Position)
+	if err != nil {
+		respond(s, i, fmt.Sprintf("❌ %v", err))
+		return
+	}
+	if err := p.Seek(target); err != nil {
+		respond(s, i, fmt.Sprintf("❌ %v", err))
+		return
+	}
+
+	if song.Duration > 0 && target >= time.Duration(song.Duration)*time.Second {
+		target = time.Duration(song.Duration-1) * time.Second
+	}
+	respond(s, i, fmt.Sprintf("⏩ Seeking to **%s** in **%s**.", player.FormatDuration(int(target/time.Second)), song.Title))
+}
+
+// parseSeekPosition reads an absolute time (90, 1:30, 1:02:03, 1m30s) or one relative to current when it starts with + or -.
+func parseSeekPosition(input string, current time.Duration) (time.Duration, error) {
+	input = strings.TrimSpace(strings.ToLower(input))
+	sign := 0
+	if strings.HasPrefix(input, "+") {
+		sign = 1
+	} else if strings.HasPrefix(input, "-") {
+		sign = -1
+	}
+	body := strings.TrimLeft(input, "+-")
+	if body == "" {
+		return 0, fmt.Errorf("give a time like 1:23 or +30s")
+	}
+
+	var d time.Duration
+	switch {
+	case strings.Contains(body, ":"):
+		parts := strings.Split(body, ":")
+		if len(parts) > 3 {
+			return 0, fmt.Errorf("%q isn't a time", input)
+		}
+		for idx, part := range parts {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 0 || (idx > 0 && n >= 60) {
+				return 0, fmt.Errorf("%q isn't a time", input)
+			}
+			d = d*60 + time.Duration(n)*time.Second
+		}
+	default:
+		if n, err := strconv.Atoi(body); err == nil {
+			d = time.Duration(n) * time.Second
+		} else if parsed, err := time.ParseDuration(body); err == nil && parsed >= 0 {
+			d = parsed
+		} else {
+			return 0, fmt.Errorf("%q isn't a time", input)
+		}
+	}
+
+	if sign != 0 {
+		d = current + time.Duration(sign)*d
+	}
+	if d < 0 {
+		d = 0
+	}
+	return d, nil
+}
+
+// progressBar draws how far through a song playback is, with the elapsed and total time.
+func progressBar(position, total time.Duration) string {
+	const width = 16
+	if total <= 0 {
+		return fmt.Sprintf("🔴 Live • %s", player.FormatDuration(int(position/time.Second)))
+	}
+	if position > total {
+		position = total
+	}
+	filled := int(int64(position) * width / int64(total))
+	if filled >= width {
+		filled = width - 1
+	}
+	bar := strings.Repeat("▬", filled) + "🔘" + strings.Repeat("▬", width-filled-1)
+	return fmt.Sprintf("%s `%s / %s`", bar, player.FormatDuration(int(position/time.Second)), player.FormatDuration(int(total/time.Second)))
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"soosa/internal/player"
+	"soosa/internal/subsonic"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	autocompleteDelay    = 300 * time.Millisecond // Wait for the user to stop typing before searching
+	autocompleteMinQuery = 2
+	autocompletePerKind  = 8
+	maxAutocompleteItems = 25 // Discord's limit on choices
+	suggestionLifetime   = 15 * time.Minute
+
+	// localFilesRefreshInterval is how often the music folder is listed again for /play file suggestions.
+	localFilesRefreshInterval = time.Minute
+)
+
+// musicSuggestion remembers what an autocomplete choice pointed at, so the ID it submits can be resolved later.
+type musicSuggestion struct {
+	Kind    string // "song", "album" or "artist"
+	Name    string
+	Created time.Time
+}
+
+var (
+	autocompleteMutex  sync.Mutex
+	autocompleteLatest = make(map[string]string)          // User ID -> latest autocomplete interaction ID
+	musicSuggestions   = make(map[string]musicSuggestion) // Subsonic ID -> what it is
+
+	localFilesMutex sync.RWMutex
+	localFiles      []string // Audio files in MUSIC_DIR, relative to it
+)
+
+// StartLocalFileIndex lists the music folder and keeps listing it again in the background, so /play file suggestions are filtered in memory instead of walking the folder on every keystroke.
+func StartLocalFileIndex(root string) {
+	if root == "" {
+		return
+	}
+	go func() {
+		for {
+			files := player.ListLocalFiles(root)
+			localFilesMutex.Lock()
+			changed := len(files) != len(localFiles)
+			localFiles = files
+			localFilesMutex.Unlock()
+			if changed {
+				log.Printf("[MUSIC] Indexed %d local files in %s", len(files), root)
+			}
+
+			time.Sleep(localFilesRefreshInterval)
+		}
+	}()
+}
+
+// searchLocalFiles returns up to limit indexed files whose paths contain query.
+func searchLocalFiles(query string, limit int) []string {
+	localFilesMutex.RLock()
+	defer localFilesMutex.RUnlock()
+	return player.FilterLocalFiles(localFiles, query, limit)
+}
+
+// HandleMusicAutocomplete suggests songs, albums and artists for the query option, and files from the music folder for /play file. Requests are debounced per user so only the last keystroke hits the server.
+func HandleMusicAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	query, focused := "", ""
+	for _, opt := range data.Options {
+		if opt.Focused {
+			query, focused = opt.StringValue(), opt.Name
+		}
+	}
+
+	userID := i.Member.User.ID
+	autocompleteMutex.Lock()
+	autocompleteLatest[userID] = i.ID
+	autocompleteMutex.Unlock()
+
+	time.Sleep(autocompleteDelay)
+
+	autocompleteMutex.Lock()
+	latest := autocompleteLatest[userID] == i.ID
+	if latest {
+		delete(autocompleteLatest, userID)
+	}
+	autocompleteMutex.Unlock()
+	if !latest {
+		// A newer keystroke superseded this one
+		return
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	if focused == "file" {
+		if hasPermission(userID, "play.external") {
+			choices = buildFileChoices(searchLocalFiles(query, maxAutocompleteItems))
+		}
+	} else if utf8.RuneCountInString(query) >= autocompleteMinQuery && hasPermission(userID, data.Name) {
+		results, err := PlayerManager.GetSubsonicClient().Search(query, autocompletePerKind)
+		if err != nil {
+			log.Printf("[MUSIC] Autocomplete search failed: %v", err)
+		} else {
+			choices = buildMusicChoices(results)
+			rememberMusicSuggestions(results)
+		}
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+// buildMusicChoices turns search results into autocomplete choices, songs first, whose values are Subsonic IDs.
+func buildMusicChoices(results *subsonic.SearchResult3) []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	add := func(name, id string) {
+		if len(choices) < maxAutocompleteItems {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: truncateChoiceName(name), Value: id})
+		}
+	}
+
+	for _, song := range results.Songs {
+		add(fmt.Sprintf("🎵 %s — %s", song.Title, song.Artist), song.ID)
+	}
+	for _, album := range results.Albums {
+		add(fmt.Sprintf("💿 %s — %s", album.Name, album.Artist), album.ID)
+	}
+	for _, artist := range results.Artists {
+		add(fmt.Sprintf("🎤 %s", artist.Name), artist.ID)
+	}
+	return choices
+}
+
+// buildFileChoices offers local files by their path in the music folder. Discord caps values at 100 characters, so longer paths are left out rather than truncated.
+func buildFileChoices(files []string) []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, file := range files {
+		if len(file) > 100 {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: truncateChoiceName("📁 " + file), Value: file})
+	}
+	return choices
+}
+
+// truncateChoiceName shortens a choice name to the 100 characters Discord allows.
+func truncateChoiceName(name string) string {
+	const limit = 100
+	if utf8.RuneCountInString(name) <= limit {
+		return name
+	}
+	runes := []rune(name)
+	return string(runes[:limit-1]) + "…"
+}
+
+func rememberMusicSuggestions(results *subsonic.SearchResult3) {
+	autocompleteMutex.Lock()
+	defer autocompleteMutex.Unlock()
+
+	now := time.Now()
+	for id, suggestion := range musicSuggestions {
+		if now.Sub(suggestion.Created) > suggestionLifetime {
+			delete(musicSuggestions, id)
+		}
+	}
+	for _, song := range results.Songs {
+		musicSuggestions[song.ID] = musicSuggestion{Kind: "song", Name: song.Title, Created: now}
+	}
+	for _, album := range results.Albums {
+		musicSuggestions[album.ID] = musicSuggestion{Kind: "album", Name: album.Name, Created: now}
+	}
+	for _, artist := range results.Artists {
+		musicSuggestions[artist.ID] = musicSuggestion{Kind: "artist", Name: artist.Name, Created: now}
+	}
+}
+
+// lookupMusicSuggestion returns what an autocomplete value points at, if it was one we suggested.
+func lookupMusicSuggestion(id string) (musicSuggestion, bool) {
+	autocompleteMutex.Lock()
+	defer autocompleteMutex.Unlock()
+	suggestion, ok := musicSuggestions[id]
+	return suggestion, ok
+}
+
+// looksLikeSubsonicID reports whether a query could be a pasted song ID rather than search text. Navidrome IDs are long runs of letters and digits.
+func looksLikeSubsonicID(query string) bool {
+	if len(query) < 16 {
+		return false
+	}
+	for _, r := range query {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
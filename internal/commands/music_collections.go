@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"soosa/internal/subsonic"
+)
+
+const (
+	artistTopSongs      = 20
+	maxCollectionTracks = 500
+)
+
+// resolveAlbum finds the best matching album and returns its tracks in order, with a description of where they came from.
+func resolveAlbum(client *subsonic.Client, query string) ([]*subsonic.Song, string, error) {
+	results, err := client.Search(query, 1)
+	if err != nil {
+		return nil, "", fmt.Errorf("search failed: %w", err)
+	}
+	if len(results.Albums) == 0 {
+		return nil, "", fmt.Errorf("no album matches **%s**", query)
+	}
+
+	return loadAlbum(client, results.Albums[0].ID)
+}
+
+// loadAlbum returns the tracks of the album with the given ID in order.
+func loadAlbum(client *subsonic.Client, id string) ([]*subsonic.Song, string, error) {
+	album, err := client.GetAlbum(id)
+	if err != nil || album == nil {
+		return nil, "", fmt.Errorf("couldn't load that album")
+	}
+	songs := songPointers(album.Songs)
+	if len(songs) == 0 {
+		return nil, "", fmt.Errorf("**%s** has no songs", album.Name)
+	}
+	return songs, fmt.Sprintf("album **%s** — %s", album.Name, album.Artist), nil
+}
+
+// resolveArtist finds the best matching artist and returns their songs as loadArtist does.
+func resolveArtist(client *subsonic.Client, query string, all bool) ([]*subsonic.Song, string, error) {
+	results, err := client.Search(query, 1)
+	if err != nil {
+		return nil, "", fmt.Errorf("search failed: %w", err)
+	}
+	if len(results.Artists) == 0 {
+		return nil, "", fmt.Errorf("no artist matches **%s**", query)
+	}
+	return loadArtist(client, results.Artists[0].ID, all)
+}
+
+// loadArtist returns either the top songs or every song on the albums of the artist with the given ID. Artists without play counts fall back to everything.
+func loadArtist(client *subsonic.Client, id string, all bool) ([]*subsonic.Song, string, error) {
+	full, err := client.GetArtist(id)
+	if err != nil || full == nil {
+		return nil, "", fmt.Errorf("couldn't load that artist")
+	}
+
+	if !all {
+		top, err := client.GetTopSongs(full.Name, artistTopSongs)
+		if err == nil && len(top) > 0 {
+			return songPointers(top), fmt.Sprintf("the top songs of **%s**", full.Name), nil
+		}
+	}
+
+	var songs []*subsonic.Song
+	for _, a := range full.Albums {
+		album, err := client.GetAlbum(a.ID)
+		if err != nil || album == nil {
+			continue
+		}
+		songs = append(songs, songPointers(album.Songs)...)
+		if len(songs) >= maxCollectionTracks {
+			songs = songs[:maxCollectionTracks]
+			break
+		}
+	}
+	if len(songs) == 0 {
+		return nil, "", fmt.Errorf("**%s** has no songs", full.Name)
+	}
+	return songs, fmt.Sprintf("**%s**", full.Name), nil
+}
+
+// resolvePlaylist finds a playlist by name and returns its songs in order.
+func resolvePlaylist(client *subsonic.Client, name string) ([]*subsonic.Song, string, error) {
+	playlists, err := client.GetPlaylists()
+	if err != nil {
+		return nil, "", fmt.Errorf("couldn't list playlists: %w", err)
+	}
+	match := matchPlaylist(playlists, name)
+	if match == nil {
+		return nil, "", fmt.Errorf("no playlist called **%s**", name)
+	}
+
+	playlist, err := client.GetPlaylist(match.ID)
+	if err != nil || playlist == nil {
+		return nil, "", fmt.Errorf("couldn't load **%s**", match.Name)
+	}
+	songs := songPointers(playlist.Songs)
+	if len(songs) == 0 {
+		return nil, "", fmt.Errorf("**%s** is empty", playlist.Name)
+	}
+	return songs, fmt.Sprintf("playlist **%s**", playlist.Name), nil
+}
+
+// matchPlaylist picks the playlist called name, as matchByName does.
+func matchPlaylist(playlists []subsonic.Playlist, name string) *subsonic.Playlist {
+	return matchByName(playlists, name, func(pl *subsonic.Playlist) string { return pl.Name })
+}
+
+// matchByName prefers an item whose name matches exactly, ignoring case, then the first one containing name.
+func matchByName[T any](items []T, name string, nameOf func(*T) string) *T {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil
+	}
+	for idx := range items {
+		if strings.ToLower(nameOf(&items[idx])) == name {
+			return &items[idx]
+		}
+	}
+	for idx := range items {
+		if strings.Contains(strings.ToLower(nameOf(&items[idx])), name) {
+			return &items[idx]
+		}
+	}
+	return nil
+}
+
+func songPointers(songs []subsonic.Song) []*subsonic.Song {
+	out := make([]*subsonic.Song, len(songs))
+	for idx := range songs {
+		out[idx] = &songs[idx]
+	}
+	return out
+}
+
+// totalDuration adds up the length of songs in seconds.
+func totalDuration(songs []*subsonic.Song) int {
+	total := 0
+	for _, song := range songs {
+		total += song.Duration
+	}
+	return total
+}
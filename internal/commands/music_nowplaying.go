@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"soosa/internal/player"
+	"soosa/internal/subsonic"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const upNextPreviewSize = 3
+
+// buildNowPlayingEmbed renders the current song with its progress, what's coming up next and the loop and volume settings. It returns nil when nothing is playing.
+func buildNowPlayingEmbed(st player.State) *discordgo.MessageEmbed {
+	song := st.NowPlaying
+	if song == nil {
+		return nil
+	}
+
+	title := "🎶 Now Playing"
+	if st.IsPaused {
+		title = "⏸️ Paused"
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: fmt.Sprintf("**%s**\n%s\n\n%s", song.Title, song.Artist, progressBar(st.Position, time.Duration(song.Duration)*time.Second)),
+		Color:       0x1DB954,
+	}
+	if song.IsLive() {
+		// Radio stations announce their own titles, when they do at all
+		nowPlaying := "Live radio"
+		if st.StreamTitle != "" {
+			nowPlaying = "🎵 " + st.StreamTitle
+		}
+		embed.Title = "📻 " + song.Title
+		if st.IsPaused {
+			embed.Title = "⏸️ " + song.Title
+		}
+		embed.Description = fmt.Sprintf("**%s**\n\n%s", nowPlaying, progressBar(st.Position, 0))
+	}
+	if song.Album != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Album", Value: song.Album, Inline: true})
+	}
+	if song.Year > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: "Year", Value: fmt.Sprintf("%d", song.Year), Inline: true,
+		})
+	}
+	embed.Fields = append(embed.Fields,
+		loopField(st.Loop),
+		&discordgo.MessageEmbedField{Name: "Volume", Value: fmt.Sprintf("🔊 %d%%", volumePercent(st.Volume)), Inline: true},
+		&discordgo.MessageEmbedField{Name: "Up Next", Value: upNextPreview(st.Queue, upNextPreviewSize)},
+	)
+	if song.CoverArt != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{
+			URL: PlayerManager.GetSubsonicClient().GetCoverArtURL(song.CoverArt, 300),
+		}
+	}
+	return embed
+}
+
+// upNextPreview lists the first n songs of the queue and how many more follow.
+func upNextPreview(queue []*subsonic.Song, n int) string {
+	if len(queue) == 0 {
+		return "Nothing queued."
+	}
+
+	var preview strings.Builder
+	for idx, song := range queue {
+		if idx >= n {
+			preview.WriteString(fmt.Sprintf("...and %d more", len(queue)-n))
+			break
+		}
+		preview.WriteString(fmt.Sprintf("`%d.` **%s** — %s (%s)\n",
+			idx+1, song.Title, song.Artist, player.FormatDuration(song.Duration)))
+	}
+	return strings.TrimSuffix(preview.String(), "\n")
+}
+
+// volumePercent converts the player's 0-256 volume to the 0-100 scale /volume uses.
+func volumePercent(volume int) int {
+	return int(float64(volume) / 256.0 * 100.0)
+}
+
+// HandlePlayerEvent keeps a guild's Now Playing message in sync with its player as songs change and play.
+func HandlePlayerEvent(s *discordgo.Session, p *player.Player, event player.Event) {
+	msgID, channelID := p.LastMessage()
+	if msgID == "" {
+		return
+	}
+	st := p.Snapshot()
+	embed := buildNowPlayingEmbed(st)
+	if embed == nil {
+		return
+	}
+
+	components := musicControlButtons(st.Loop)
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         msgID,
+		Channel:    channelID,
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		var restErr *discordgo.RESTError
+		if errors.As(err, &restErr) && restErr.Message != nil && restErr.Message.Code == discordgo.ErrCodeUnknownMessage {
+			// The message was deleted, so stop trying to update it
+			p.SetLastMessage("", "")
+		}
+		log.Printf("[MUSIC] Failed to update Now Playing message in %s: %v", channelID, err)
+	}
+}
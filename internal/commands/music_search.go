@@ -0,0 +1,364 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"soosa/internal/player"
+	"soosa/internal/subsonic"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	searchResultsPerKind = 50
+	searchPageSize       = 10
+	searchLifetime       = 15 * time.Minute
+)
+
+// Search tabs, one per kind of result.
+const (
+	searchTabSongs   = "songs"
+	searchTabAlbums  = "albums"
+	searchTabArtists = "artists"
+)
+
+var searchTabs = []struct {
+	Name  string
+	Label string
+	Emoji string
+}{
+	{searchTabSongs, "Songs", "🎵"},
+	{searchTabAlbums, "Albums", "💿"},
+	{searchTabArtists, "Artists", "🎤"},
+}
+
+// MusicSearch is the state behind an interactive /search message.
+type MusicSearch struct {
+	Query   string
+	Results *subsonic.SearchResult3
+	Tab     string
+	Page    int
+	Created time.Time
+}
+
+// searchEntry is one line of a search page and one option in its select menu.
+type searchEntry struct {
+	ID     string
+	Label  string
+	Detail string
+}
+
+var (
+	musicSearches = make(map[string]*MusicSearch) // Keyed by message ID
+	searchMutex   sync.Mutex
+)
+
+// countFor returns how many results a tab has.
+func (m *MusicSearch) countFor(tab string) int {
+	switch tab {
+	case searchTabAlbums:
+		return len(m.Results.Albums)
+	case searchTabArtists:
+		return len(m.Results.Artists)
+	default:
+		return len(m.Results.Songs)
+	}
+}
+
+// pages returns how many pages the current tab takes, never less than one.
+func (m *MusicSearch) pages() int {
+	n := m.countFor(m.Tab)
+	if n == 0 {
+		return 1
+	}
+	return (n + searchPageSize - 1) / searchPageSize
+}
+
+// entries returns the results on the current page of the current tab, clamping the page into range first.
+func (m *MusicSearch) entries() []searchEntry {
+	if m.Page >= m.pages() {
+		m.Page = m.pages() - 1
+	}
+	if m.Page < 0 {
+		m.Page = 0
+	}
+	start := m.Page * searchPageSize
+	end := min(start+searchPageSize, m.countFor(m.Tab))
+
+	var entries []searchEntry
+	for idx := start; idx < end; idx++ {
+		switch m.Tab {
+		case searchTabAlbums:
+			album := m.Results.Albums[idx]
+			entries = append(entries, searchEntry{album.ID, album.Name, fmt.Sprintf("%s • %d songs", album.Artist, album.SongCount)})
+		case searchTabArtists:
+			artist := m.Results.Artists[idx]
+			entries = append(entries, searchEntry{artist.ID, artist.Name, fmt.Sprintf("%d albums", artist.AlbumCount)})
+		default:
+			song := m.Results.Songs[idx]
+			entries = append(entries, searchEntry{song.ID, song.Title, fmt.Sprintf("%s • %s", song.Artist, player.FormatDuration(song.Duration))})
+		}
+	}
+	return entries
+}
+
+// firstTabWithResults picks songs when there are any, otherwise the first tab that has something.
+func firstTabWithResults(results *subsonic.SearchResult3) string {
+	m := &MusicSearch{Results: results}
+	for _, tab := range searchTabs {
+		if m.countFor(tab.Name) > 0 {
+			return tab.Name
+		}
+	}
+	return searchTabSongs
+}
+
+// buildSearchMessage renders the current page of a search with its select menu, tab buttons and page buttons.
+func buildSearchMessage(m *MusicSearch) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	entries := m.entries()
+
+	var lines []string
+	for idx, entry := range entries {
+		lines = append(lines, fmt.Sprintf("`%d.` **%s** — %s", m.Page*searchPageSize+idx+1, entry.Label, entry.Detail))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "Nothing here.")
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🔎 Search Results for \"%s\"", m.Query),
+		Description: strings.Join(lines, "\n"),
+		Color:       0x5865F2,
+		Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Page %d/%d • Pick results below to queue them", m.Page+1, m.pages())},
+	}
+
+	var components []discordgo.MessageComponent
+	if len(entries) > 0 {
+		options := make([]discordgo.SelectMenuOption, len(entries))
+		for idx, entry := range entries {
+			options[idx] = discordgo.SelectMenuOption{
+				Label:       truncateChoiceName(entry.Label),
+				Value:       entry.ID,
+				Description: truncateChoiceName(entry.Detail),
+			}
+		}
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:    discordgo.StringSelectMenu,
+					CustomID:    "music_search_pick",
+					Placeholder: fmt.Sprintf("Queue %s...", m.Tab),
+					MaxValues:   len(options),
+					Options:     options,
+				},
+			},
+		})
+	}
+
+	var tabs []discordgo.MessageComponent
+	for _, tab := range searchTabs {
+		style := discordgo.SecondaryButton
+		if tab.Name == m.Tab {
+			style = discordgo.PrimaryButton
+		}
+		tabs = append(tabs, discordgo.Button{
+			Label:    fmt.Sprintf("%s (%d)", tab.Label, m.countFor(tab.Name)),
+			Emoji:    &discordgo.ComponentEmoji{Name: tab.Emoji},
+			Style:    style,
+			CustomID: "music_search_tab:" + tab.Name,
+			Disabled: tab.Name == m.Tab || m.countFor(tab.Name) == 0,
+		})
+	}
+	tabs = append(tabs,
+		discordgo.Button{
+			Label:    "Prev",
+			Emoji:    &discordgo.ComponentEmoji{Name: "◀️"},
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("music_search_page:%d", m.Page-1),
+			Disabled: m.Page == 0,
+		},
+		discordgo.Button{
+			Label:    "Next",
+			Emoji:    &discordgo.ComponentEmoji{Name: "▶️"},
+			Style:    discordgo.SecondaryButton,
+			CustomID: fmt.Sprintf("music_search_page:%d", m.Page+1),
+			Disabled: m.Page >= m.pages()-1,
+		},
+	)
+	components = append(components, discordgo.ActionsRow{Components: tabs})
+
+	return embed, components
+}
+
+func handleSearch(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	query := data.Options[0].StringValue()
+	if suggestion, ok := lookupMusicSuggestion(query); ok {
+		// Search for the name of the picked suggestion rather than its ID
+		query = suggestion.Name
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	subClient := PlayerManager.GetSubsonicClient()
+	results, err := subClient.Search(query, searchResultsPerKind)
+	if err != nil {
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: strPtr(fmt.Sprintf("❌ Search failed: %v", err)),
+		})
+		return
+	}
+
+	if len(results.Songs) == 0 && len(results.Albums) == 0 && len(results.Artists) == 0 {
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: strPtr(fmt.Sprintf("❌ No results found for: **%s**", query)),
+		})
+		return
+	}
+
+	search := &MusicSearch{Query: query, Results: results, Tab: firstTabWithResults(results), Created: time.Now()}
+	embed, components := buildSearchMessage(search)
+	msg, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil || msg == nil {
+		return
+	}
+
+	searchMutex.Lock()
+	for id, old := range musicSearches {
+		if time.Since(old.Created) > searchLifetime {
+			delete(musicSearches, id)
+		}
+	}
+	musicSearches[msg.ID] = search
+	searchMutex.Unlock()
+}
+
+// handleSearchComponent switches tabs and pages on a search message, or queues the picked results.
+func handleSearchComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.MessageComponentData()
+
+	searchMutex.Lock()
+	search, ok := musicSearches[i.Message.ID]
+	searchMutex.Unlock()
+	if !ok {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    "⌛ This search has expired. Run /search again.",
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+		return
+	}
+
+	if data.CustomID == "music_search_pick" {
+		searchMutex.Lock()
+		tab := search.Tab
+		searchMutex.Unlock()
+		queueSearchPicks(s, i, search, tab, data.Values)
+		return
+	}
+
+	searchMutex.Lock()
+	if tab, ok := strings.CutPrefix(data.CustomID, "music_search_tab:"); ok {
+		search.Tab = tab
+		search.Page = 0
+	} else {
+		fmt.Sscanf(data.CustomID, "music_search_page:%d", &search.Page)
+	}
+	embed, components := buildSearchMessage(search)
+	searchMutex.Unlock()
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	})
+}
+
+// queueSearchPicks enqueues the songs, albums or artists picked from a search, starting playback if nothing is playing.
+func queueSearchPicks(s *discordgo.Session, i *discordgo.InteractionCreate, search *MusicSearch, tab string, ids []string) {
+	if !hasPermission(i.Member.User.ID, "play") {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "🚫 You do not have permission to play music.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	channelID := findUserVoiceChannel(s, i.GuildID, i.Member.User.ID)
+	if channelID == "" {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: "❌ You must be in a voice channel to play music.",
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	// Albums and artists take a few requests to load
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	subClient := PlayerManager.GetSubsonicClient()
+	var songs []*subsonic.Song
+	var names []string
+	for _, id := range ids {
+		switch tab {
+		case searchTabAlbums:
+			albumSongs, source, err := loadAlbum(subClient, id)
+			if err != nil {
+				log.Printf("[MUSIC] Failed to load album %s from search: %v", id, err)
+				continue
+			}
+			songs = append(songs, albumSongs...)
+			names = append(names, source)
+		case searchTabArtists:
+			artistSongs, source, err := loadArtist(subClient, id, false)
+			if err != nil {
+				log.Printf("[MUSIC] Failed to load artist %s from search: %v", id, err)
+				continue
+			}
+			songs = append(songs, artistSongs...)
+			names = append(names, source)
+		default:
+			for idx := range search.Results.Songs {
+				if search.Results.Songs[idx].ID == id {
+					song := search.Results.Songs[idx]
+					songs = append(songs, &song)
+					names = append(names, fmt.Sprintf("**%s** — %s", song.Title, song.Artist))
+					break
+				}
+			}
+		}
+	}
+
+	if len(songs) == 0 {
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: strPtr("❌ Couldn't load any of those."),
+		})
+		return
+	}
+
+	summary := fmt.Sprintf("🎵 Added to queue: %s (%s)", strings.Join(names, ", "), player.FormatDuration(totalDuration(songs)))
+	if len(songs) > 1 {
+		summary = fmt.Sprintf("📀 Queued **%d** songs from %s (%s)", len(songs), strings.Join(names, ", "), player.FormatDuration(totalDuration(songs)))
+	}
+	playSongs(s, i, channelID, songs, summary)
+}
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"soosa/internal/subsonic"
+)
+
+func TestParseSeekPosition(t *testing.T) {
+	current := 90 * time.Second
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"83", 83 * time.Second, false},
+		{"1:23", 83 * time.Second, false},
+		{"1:02:03", time.Hour + 2*time.Minute + 3*time.Second, false},
+		{"1m30s", 90 * time.Second, false},
+		{"+30s", 120 * time.Second, false},
+		{"+1:00", 150 * time.Second, false},
+		{"-15", 75 * time.Second, false},
+		{"-5m", 0, false},
+		{"1:75", 0, true},
+		{"soon", 0, true},
+		{"+", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSeekPosition(tt.input, current)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSeekPosition(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseSeekPosition(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		position, total time.Duration
+		wantPrefix      string
+		wantTime        string
+	}{
+		{0, 4 * time.Minute, "🔘", "0:00 / 4:00"},
+		{2 * time.Minute, 4 * time.Minute, strings.Repeat("▬", 8) + "🔘", "2:00 / 4:00"},
+		{5 * time.Minute, 4 * time.Minute, strings.Repeat("▬", 15) + "🔘", "4:00 / 4:00"},
+	}
+
+	for _, tt := range tests {
+		got := progressBar(tt.position, tt.total)
+		if !strings.HasPrefix(got, tt.wantPrefix) || !strings.Contains(got, tt.wantTime) {
+			t.Errorf("progressBar(%v, %v) = %q", tt.position, tt.total, got)
+		}
+	}
+
+	if got := progressBar(30*time.Second, 0); !strings.Contains(got, "0:30") {
+		t.Errorf("progressBar without a length = %q, want the elapsed time", got)
+	}
+}
+
+func TestMatchPlaylist(t *testing.T) {
+	playlists := []subsonic.Playlist{
+		{ID: "1", Name: "Study Beats"},
+		{ID: "2", Name: "Beats"},
+		{ID: "3", Name: "Road Trip"},
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"beats", "2"},
+		{"  ROAD trip ", "3"},
+		{"study", "1"},
+		{"jazz", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		got := matchPlaylist(playlists, tt.name)
+		gotID := ""
+		if got != nil {
+			gotID = got.ID
+		}
+		if gotID != tt.want {
+			t.Errorf("matchPlaylist(%q) = %q, want %q", tt.name, gotID, tt.want)
+		}
+	}
+}
+
+func TestBuildMusicChoices(t *testing.T) {
+	results := &subsonic.SearchResult3{
+		Songs:   []subsonic.Song{{ID: "s1", Title: "Song", Artist: "Band"}},
+		Albums:  []subsonic.Album{{ID: "al1", Name: "Record", Artist: "Band"}},
+		Artists: []subsonic.Artist{{ID: "ar1", Name: "Band"}},
+	}
+	choices := buildMusicChoices(results)
+	want := []string{"s1", "al1", "ar1"}
+	if len(choices) != len(want) {
+		t.Fatalf("got %d choices, want %d", len(choices), len(want))
+	}
+	for idx, id := range want {
+		if choices[idx].Value != id {
+			t.Errorf("choice %d value = %v, want %s", idx, choices[idx].Value, id)
+		}
+	}
+
+	for n := 0; n < 30; n++ {
+		results.Songs = append(results.Songs, subsonic.Song{ID: "x", Title: strings.Repeat("long ", 30)})
+	}
+	choices = buildMusicChoices(results)
+	if len(choices) != maxAutocompleteItems {
+		t.Errorf("got %d choices, want the limit of %d", len(choices), maxAutocompleteItems)
+	}
+	for _, c := range choices {
+		if n := len([]rune(c.Name)); n > 100 {
+			t.Errorf("choice name is %d characters", n)
+		}
+	}
+}
+
+func TestLooksLikeSubsonicID(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"3zE5yXBUqNUTGHHbR8nNRV", true},
+		{"f3b5c9a1d2e4f6a7b8c9d0e1f2a3b4c5", true},
+		{"Thriller", false},
+		{"bohemian rhapsody queen", false},
+		{"supercalifragilistic!", false},
+	}
+	for _, tt := range tests {
+		if got := looksLikeSubsonicID(tt.query); got != tt.want {
+			t.Errorf("looksLikeSubsonicID(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMusicSearchPages(t *testing.T) {
+	results := &subsonic.SearchResult3{
+		Albums: []subsonic.Album{{ID: "al1", Name: "Record"}},
+	}
+	for n := 0; n < 23; n++ {
+		results.Songs = append(results.Songs, subsonic.Song{ID: fmt.Sprintf("s%d", n), Title: "Song"})
+	}
+
+	search := &MusicSearch{Query: "q", Results: results, Tab: firstTabWithResults(results)}
+	if search.Tab != searchTabSongs {
+		t.Fatalf("first tab = %s, want songs", search.Tab)
+	}
+	if got := search.pages(); got != 3 {
+		t.Errorf("pages() = %d, want 3", got)
+	}
+
+	search.Page = 5
+	entries := search.entries()
+	if search.Page != 2 || len(entries) != 3 || entries[0].ID != "s20" {
+		t.Errorf("last page = %d with %d entries starting %v", search.Page, len(entries), entries)
+	}
+
+	search.Tab = searchTabAlbums
+	search.Page = 0
+	if entries := search.entries(); len(entries) != 1 || entries[0].ID != "al1" {
+		t.Errorf("album entries = %v", entries)
+	}
+
+	search.Tab = searchTabArtists
+	_, components := buildSearchMessage(search)
+	if len(components) != 1 {
+		t.Errorf("an empty tab should only have buttons, got %d rows", len(components))
+	}
+
+	if tab := firstTabWithResults(&subsonic.SearchResult3{Artists: []subsonic.Artist{{ID: "a"}}}); tab != searchTabArtists {
+		t.Errorf("first tab for only artists = %s", tab)
+	}
+}
+
+func TestUpNextPreview(t *testing.T) {
+	song := func(title string) *subsonic.Song {
+		return &subsonic.Song{Title: title, Artist: "Band", Duration: 61}
+	}
+
+	if got := upNextPreview(nil, 3); got != "Nothing queued." {
+		t.Errorf("empty queue preview = %q", got)
+	}
+
+	got := upNextPreview([]*subsonic.Song{song("A"), song("B")}, 3)
+	if want := "`1.` **A** — Band (1:01)\n`2.` **B** — Band (1:01)"; got != want {
+		t.Errorf("short queue preview = %q, want %q", got, want)
+	}
+
+	got = upNextPreview([]*subsonic.Song{song("A"), song("B"), song("C"), song("D"), song("E")}, 3)
+	if !strings.Contains(got, "**C**") || strings.Contains(got, "**D**") || !strings.HasSuffix(got, "...and 2 more") {
+		t.Errorf("long queue preview = %q", got)
+	}
+}
+
+func TestMatchStation(t *testing.T) {
+	stations := []subsonic.InternetRadioStation{
+		{ID: "1", Name: "Jazz FM"},
+		{ID: "2", Name: "Jazz"},
+		{ID: "3", Name: "Lofi Beats"},
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"jazz", "2"},
+		{"JAZZ fm", "1"},
+		{"lofi", "3"},
+		{"metal", ""},
+	}
+	for _, tt := range tests {
+		got := matchStation(stations, tt.name)
+		gotID := ""
+		if got != nil {
+			gotID = got.ID
+		}
+		if gotID != tt.want {
+			t.Errorf("matchStation(%q) = %q, want %q", tt.name, gotID, tt.want)
+		}
+	}
+
+	song := stationSong(&stations[0])
+	if song.Duration != 0 || !song.IsLive() || song.Title != "Jazz FM" {
+		t.Errorf("stationSong = %+v", song)
+	}
+}
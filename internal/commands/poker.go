@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 	"log"
-	"math/rand"
 	"sort"
 	"strconv"
 	"strings"
@@ -65,6 +64,8 @@ type PokerPlayer struct {
 	CurrentBet int // Bet in current round
 	TotalBet   int // Total bet in entire game
 	Status     PokerPlayerStatus
+	IsBot      bool
+	BotLevel   PokerBotLevel
 }
 
 type PokerGame struct {
@@ -85,6 +86,14 @@ type PokerGame struct {
 	ResultText   string
 	StartTime    time.Time
 	LobbyTimer   *time.Timer
+	BotTimer     *time.Timer
+	Fair         *FairDeal // Set when the hand was dealt in provably fair mode
+	PlayMoney    bool      // Set once a bot sits down: nobody pays a buy-in and nothing is paid out
+
+	ActionLog  []string
+	Snapshots  []pokerSnapshot
+	Spectators map[string]*pokerSpectator
+	Refreshing bool // Whether the spectator refresh loop is running
 }
 
 var (
@@ -147,6 +156,7 @@ func HandlePokerCommand(s *discordgo.Session, i *discordgo.InteractionCreate, da
 			Status:   PlayerPlaying,
 		}},
 		StartTime: time.Now().Add(pokerLobbyTime),
+		Fair:      newFairCommitment(),
 	}
 	log.Printf("[POKER LOBBY] Created by %s | Buyin: %d", i.Member.User.Username, buyin)
 
@@ -188,7 +198,7 @@ func HandlePokerCommand(s *discordgo.Session, i *discordgo.InteractionCreate, da
 			} else {
 				// Refund all players
 				for _, p := range g.Players {
-					DB.AddBalance(p.UserID, p.Stack)
+					creditPokerPlayer(g, p, p.Stack)
 				}
 				content := "Game cancelled: Not enough players."
 				emptyEmbeds := []*discordgo.MessageEmbed{}
@@ -210,22 +220,48 @@ func HandlePokerCommand(s *discordgo.Session, i *discordgo.InteractionCreate, da
 func createPokerLobbyEmbed(g *PokerGame) *discordgo.MessageEmbed {
 	var playersList []string
 	for _, p := range g.Players {
-		playersList = append(playersList, fmt.Sprintf("- %s ($%d)", p.Username, p.Stack))
+		name := p.Username
+		if p.IsBot {
+			name = "🤖 " + name
+		}
+		playersList = append(playersList, fmt.Sprintf("- %s ($%d)", name, p.Stack))
+	}
+
+	description := fmt.Sprintf("Waiting for players... Starts <t:%d:R>\n\n**Players (%d/%d):**\n%s", g.StartTime.Unix(), len(g.Players), maxPokerPlayers, strings.Join(playersList, "\n"))
+	if g.PlayMoney {
+		description += "\n\n🎲 **Play money:** bots are seated, so buy-ins were refunded and nothing is paid out."
 	}
 
-	return &discordgo.MessageEmbed{
+	embed := &discordgo.MessageEmbed{
 		Title:       "Poker Lobby",
-		Description: fmt.Sprintf("Waiting for players... Starts <t:%d:R>\n\n**Players (%d/%d):**\n%s", g.StartTime.Unix(), len(g.Players), maxPokerPlayers, strings.Join(playersList, "\n")),
+		Description: description,
 		Color:       0x5865F2,
 	}
+	if g.Fair != nil {
+		embed.Fields = append(embed.Fields, fairLobbyField(g.Fair))
+	}
+	return embed
 }
 
 func createPokerLobbyButtons() []discordgo.MessageComponent {
 	return []discordgo.MessageComponent{
 		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
+			Components: append([]discordgo.MessageComponent{
 				discordgo.Button{Label: "Join", Style: discordgo.PrimaryButton, CustomID: "game_poker_join"},
 				discordgo.Button{Label: "Start", Style: discordgo.SuccessButton, CustomID: "game_poker_start"},
+			}, fairSeedButton("game_poker_seed")...),
+		},
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    "game_poker_add_bot",
+					Placeholder: "Add a bot (host only)",
+					Options: []discordgo.SelectMenuOption{
+						{Label: "Easy Bot", Value: string(BotEasy), Emoji: &discordgo.ComponentEmoji{Name: "🤖"}},
+						{Label: "Medium Bot", Value: string(BotMedium), Emoji: &discordgo.ComponentEmoji{Name: "🤖"}},
+						{Label: "Hard Bot", Value: string(BotHard), Emoji: &discordgo.ComponentEmoji{Name: "🤖"}},
+					},
+				},
 			},
 		},
 	}
@@ -261,6 +297,14 @@ func HandlePokerComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 		handlePokerJoin(s, i, game)
 	case "game_poker_start":
 		handlePokerStartButton(s, i, game)
+	case "game_poker_add_bot":
+		handlePokerAddBot(s, i, game)
+	case "game_poker_seed":
+		handlePokerSeedButton(s, i, game)
+	case "game_poker_spectate":
+		handlePokerSpectate(s, i, game, false)
+	case "game_poker_spectate_delayed":
+		handlePokerSpectate(s, i, game, true)
 	case "game_poker_reveal":
 		handlePokerReveal(s, i, game)
 	case "game_poker_fold":
@@ -313,6 +357,7 @@ func handlePokerPlayAgain(s *discordgo.Session, i *discordgo.InteractionCreate,
 			Status:   PlayerPlaying,
 		}},
 		StartTime: time.Now().Add(pokerLobbyTime),
+		Fair:      newFairCommitment(),
 	}
 
 	embed := createPokerLobbyEmbed(newGame)
@@ -350,7 +395,7 @@ func handlePokerPlayAgain(s *discordgo.Session, i *discordgo.InteractionCreate,
 				startPokerGame(s, g, nil)
 			} else {
 				for _, p := range g.Players {
-					DB.AddBalance(p.UserID, p.Stack)
+					creditPokerPlayer(g, p, p.Stack)
 				}
 				content := "Game cancelled: Not enough players."
 				emptyEmbeds := []*discordgo.MessageEmbed{}
@@ -400,6 +445,25 @@ func handlePokerJoin(s *discordgo.Session, i *discordgo.InteractionCreate, g *Po
 	}
 
 	buyin := g.Players[0].Stack
+	if g.PlayMoney {
+		// Bot tables are free to join and pay nothing out
+		g.Players = append(g.Players, &PokerPlayer{
+			UserID:   i.Member.User.ID,
+			Username: i.Member.User.Username,
+			Stack:    buyin,
+			Status:   PlayerPlaying,
+		})
+		log.Printf("[POKER JOIN] %s joined play money game %s", i.Member.User.Username, g.MessageID)
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{createPokerLobbyEmbed(g)},
+				Components: createPokerLobbyButtons(),
+			},
+		})
+		return
+	}
+
 	bal, err := DB.GetBalance(i.Member.User.ID)
 	if err != nil || bal < buyin {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -466,15 +530,23 @@ func handlePokerStartButton(s *discordgo.Session, i *discordgo.InteractionCreate
 }
 
 func startPokerGame(s *discordgo.Session, g *PokerGame, i *discordgo.InteractionCreate) {
+	dealPokerHand(g, gameDeck(g.Fair), DefaultShuffler.Intn(len(g.Players)))
+
+	updatePokerGameMessage(s, g, i)
+	schedulePokerBotTurn(s, g)
+}
+
+// dealPokerHand deals hole cards from the given deck, places the button and posts the blinds. It does no I/O so hands can be set up deterministically.
+func dealPokerHand(g *PokerGame, deck []Card, button int) {
 	g.Stage = StagePreFlop
-	g.Deck = NewDeck()
+	g.Deck = deck
 
 	for _, p := range g.Players {
 		p.HoleCards = []Card{g.Deck[0], g.Deck[1]}
 		g.Deck = g.Deck[2:]
 	}
 
-	g.DealerButton = rand.Intn(len(g.Players))
+	g.DealerButton = button
 
 	sbIdx := (g.DealerButton + 1) % len(g.Players)
 	bbIdx := (g.DealerButton + 2) % len(g.Players)
@@ -485,11 +557,10 @@ func startPokerGame(s *discordgo.Session, g *PokerGame, i *discordgo.Interaction
 
 	postBlind(g.Players[sbIdx], g.SmallBlind, g)
 	postBlind(g.Players[bbIdx], g.BigBlind, g)
+	logPokerAction(g, "Dealt %d players. %s posts small blind $%d, %s posts big blind $%d.", len(g.Players), g.Players[sbIdx].Username, g.SmallBlind, g.Players[bbIdx].Username, g.BigBlind)
 
 	g.MinBet = g.BigBlind
 	g.CurrentTurn = (bbIdx + 1) % len(g.Players)
-
-	updatePokerGameMessage(s, g, i)
 }
 
 func postBlind(p *PokerPlayer, amount int, g *PokerGame) {
@@ -502,7 +573,8 @@ func postBlind(p *PokerPlayer, amount int, g *PokerGame) {
 	p.TotalBet += amount
 }
 
-func updatePokerGameMessage(s *discordgo.Session, g *PokerGame, i *discordgo.InteractionCreate) {
+// buildPokerTableEmbed renders the table. Hole cards are shown at showdown, or for every seat when showHoleCards is set.
+func buildPokerTableEmbed(g *PokerGame, showHoleCards bool) *discordgo.MessageEmbed {
 	embed := &discordgo.MessageEmbed{
 		Title: "Poker",
 		Color: 0x00FF00,
@@ -530,6 +602,7 @@ func updatePokerGameMessage(s *discordgo.Session, g *PokerGame, i *discordgo.Int
 	if g.ResultText != "" {
 		embed.Description += "\n\n" + g.ResultText
 	}
+	embed.Footer = fairDealFooter(g.Fair)
 
 	for idx, p := range g.Players {
 		status := ""
@@ -546,7 +619,7 @@ func updatePokerGameMessage(s *discordgo.Session, g *PokerGame, i *discordgo.Int
 		}
 
 		val := fmt.Sprintf("Bet: $%d", p.CurrentBet)
-		if g.Stage == StageShowdown && p.Status != PlayerFolded {
+		if (g.Stage == StageShowdown && p.Status != PlayerFolded) || (showHoleCards && len(p.HoleCards) > 0) {
 			// Reveal cards
 			handStr := ""
 			for _, c := range p.HoleCards {
@@ -555,13 +628,25 @@ func updatePokerGameMessage(s *discordgo.Session, g *PokerGame, i *discordgo.Int
 			val = fmt.Sprintf("Cards: %s\nBet: $%d", handStr, p.CurrentBet)
 		}
 
+		name := p.Username
+		if p.IsBot {
+			name = "🤖 " + name
+		}
+
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   fmt.Sprintf("%s ($%d) %s", p.Username, p.Stack, status),
+			Name:   fmt.Sprintf("%s ($%d) %s", name, p.Stack, status),
 			Value:  val,
 			Inline: false,
 		})
 	}
 
+	return embed
+}
+
+func updatePokerGameMessage(s *discordgo.Session, g *PokerGame, i *discordgo.InteractionCreate) {
+	embed := buildPokerTableEmbed(g, false)
+	recordPokerSnapshot(g)
+
 	components := []discordgo.MessageComponent{
 		discordgo.ActionsRow{
 			Components: []discordgo.MessageComponent{
@@ -571,6 +656,7 @@ func updatePokerGameMessage(s *discordgo.Session, g *PokerGame, i *discordgo.Int
 				discordgo.Button{Label: "Raise", Style: discordgo.SuccessButton, CustomID: "game_poker_raise_modal"},
 			},
 		},
+		createPokerSpectateButtons(),
 	}
 
 	if g.Stage == StageShowdown {
@@ -642,6 +728,7 @@ func handlePokerFold(s *discordgo.Session, i *discordgo.InteractionCreate, g *Po
 
 	activePlayer.Status = PlayerFolded
 	log.Printf("[POKER ACTION] %s FOLDED", i.Member.User.Username)
+	logPokerAction(g, "%s folds.", activePlayer.Username)
 	nextPokerTurn(s, g, i)
 }
 
@@ -658,15 +745,8 @@ func handlePokerCall(s *discordgo.Session, i *discordgo.InteractionCreate, g *Po
 		return
 	}
 
-	callAmount := g.MinBet - activePlayer.CurrentBet
-	if callAmount > activePlayer.Stack {
-		callAmount = activePlayer.Stack
-		activePlayer.Status = PlayerAllIn
-	}
-
-	activePlayer.Stack -= callAmount
-	activePlayer.CurrentBet += callAmount
-	activePlayer.TotalBet += callAmount
+	callAmount := applyPokerCall(g, activePlayer)
+	logPokerCall(g, activePlayer, callAmount)
 
 	log.Printf("[POKER ACTION] %s %s | Amount: %d", i.Member.User.Username, func() string {
 		if callAmount == 0 {
@@ -678,6 +758,54 @@ func handlePokerCall(s *discordgo.Session, i *discordgo.InteractionCreate, g *Po
 	nextPokerTurn(s, g, i)
 }
 
+// applyPokerCall matches the current bet for p (going all-in if short) and returns the amount put in.
+func applyPokerCall(g *PokerGame, p *PokerPlayer) int {
+	callAmount := g.MinBet - p.CurrentBet
+	if callAmount > p.Stack {
+		callAmount = p.Stack
+		p.Status = PlayerAllIn
+	}
+
+	p.Stack -= callAmount
+	p.CurrentBet += callAmount
+	p.TotalBet += callAmount
+	return callAmount
+}
+
+// applyPokerRaise raises p's bet for this round to target (capped at their stack) and updates the table minimum.
+func applyPokerRaise(g *PokerGame, p *PokerPlayer, target int) {
+	raiseAmount := target - p.CurrentBet
+	if raiseAmount > p.Stack {
+		raiseAmount = p.Stack
+		p.Status = PlayerAllIn
+	}
+
+	p.Stack -= raiseAmount
+	p.CurrentBet += raiseAmount
+	p.TotalBet += raiseAmount
+
+	if p.CurrentBet > g.MinBet {
+		g.LastRaise = p.CurrentBet - g.MinBet
+		g.MinBet = p.CurrentBet
+	}
+}
+
+// creditPokerPlayer pays out chips to a player's balance. Tables with bots play for chips only, so nothing leaves them, and bot seats never have a balance to pay into.
+func creditPokerPlayer(g *PokerGame, p *PokerPlayer, amount int) {
+	if g.PlayMoney || p.IsBot || amount <= 0 {
+		return
+	}
+	DB.AddBalance(p.UserID, amount)
+}
+
+// playMoneyNote reminds a bot table that its results didn't change anyone's balance.
+func playMoneyNote(g *PokerGame) string {
+	if !g.PlayMoney {
+		return ""
+	}
+	return "\n🎲 Play money game: no balances changed."
+}
+
 func handlePokerRaiseModalTrigger(s *discordgo.Session, i *discordgo.InteractionCreate, g *PokerGame) {
 	activePlayer := g.Players[g.CurrentTurn]
 	if i.Member.User.ID != activePlayer.UserID {
@@ -710,8 +838,49 @@ func handlePokerRaiseModalTrigger(s *discordgo.Session, i *discordgo.Interaction
 	})
 }
 
+// handlePokerSeedButton asks a player for a client seed while the lobby is open.
+func handlePokerSeedButton(s *discordgo.Session, i *discordgo.InteractionCreate, g *PokerGame) {
+	pokerMutex.Lock()
+	open := g.Stage == StageLobby && g.Fair != nil
+	pokerMutex.Unlock()
+	if !open {
+		respondError(s, i, "Seeds can only be added before the deal.")
+		return
+	}
+	openFairSeedModal(s, i, "game_poker_seed_modal_"+g.MessageID)
+}
+
+// handlePokerSeedModal adds a submitted client seed to the lobby's deal.
+func handlePokerSeedModal(s *discordgo.Session, i *discordgo.InteractionCreate, msgID string) {
+	pokerMutex.Lock()
+	defer pokerMutex.Unlock()
+
+	g, exists := activePokerGames[msgID]
+	if !exists || g.Stage != StageLobby || g.Fair == nil {
+		respondError(s, i, "Seeds can only be added before the deal.")
+		return
+	}
+	if err := g.Fair.AddClientSeed(fairSeedModalValue(i)); err != nil {
+		respondError(s, i, "Couldn't add that seed: "+err.Error()+".")
+		return
+	}
+	log.Printf("[POKER FAIR] %s added a client seed to game %s", i.Member.User.Username, g.MessageID)
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{createPokerLobbyEmbed(g)},
+			Components: createPokerLobbyButtons(),
+		},
+	})
+}
+
 func HandlePokerModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	data := i.ModalSubmitData()
+	if msgID, ok := strings.CutPrefix(data.CustomID, "game_poker_seed_modal_"); ok {
+		handlePokerSeedModal(s, i, msgID)
+		return
+	}
 	if !strings.HasPrefix(data.CustomID, "game_poker_raise_modal_") {
 		return
 	}
@@ -742,20 +911,8 @@ func HandlePokerModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		return
 	}
 
-	raiseAmount := amount - activePlayer.CurrentBet
-	if raiseAmount > activePlayer.Stack {
-		raiseAmount = activePlayer.Stack
-		activePlayer.Status = PlayerAllIn
-	}
-
-	activePlayer.Stack -= raiseAmount
-	activePlayer.CurrentBet += raiseAmount
-	activePlayer.TotalBet += raiseAmount
-
-	if activePlayer.CurrentBet > g.MinBet {
-		g.LastRaise = activePlayer.CurrentBet - g.MinBet
-		g.MinBet = activePlayer.CurrentBet
-	}
+	applyPokerRaise(g, activePlayer, amount)
+	logPokerAction(g, "%s raises to $%d.", activePlayer.Username, activePlayer.CurrentBet)
 
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseUpdateMessage,
@@ -810,6 +967,7 @@ func nextPokerTurn(s *discordgo.Session, g *PokerGame, i *discordgo.InteractionC
 	}
 
 	updatePokerGameMessage(s, g, i)
+	schedulePokerBotTurn(s, g)
 }
 
 func advancePokerStage(s *discordgo.Session, g *PokerGame) {
@@ -834,9 +992,11 @@ func advancePokerStage(s *discordgo.Session, g *PokerGame) {
 		g.Deck = g.Deck[1:]
 	case StageRiver:
 		g.Stage = StageShowdown
+		logPokerAction(g, "Showdown.")
 		determinePokerWinners(s, g)
 		return // Stop the flow here so we don't try to calculate next turn
 	}
+	logPokerAction(g, "%s: %s", g.Stage, formatHand(g.CommunityCards))
 
 	g.CurrentTurn = (g.DealerButton + 1) % len(g.Players)
 	for g.Players[g.CurrentTurn].Status != PlayerPlaying {
@@ -844,7 +1004,8 @@ func advancePokerStage(s *discordgo.Session, g *PokerGame) {
 	}
 }
 
-func determinePokerWinners(s *discordgo.Session, g *PokerGame) {
+// splitPokerPots builds the main and side pots from each player's total bet and returns how much each player wins at showdown, keyed by user ID.
+func splitPokerPots(g *PokerGame) map[string]int {
 	// Calculate Side Pots Logic We dynamically calculate pots based on TotalBet of each player.
 
 	type PotCandidate struct {
@@ -897,12 +1058,9 @@ func determinePokerWinners(s *discordgo.Session, g *PokerGame) {
 	}
 
 	// Distribute each pot
-	var resultBuilder strings.Builder
-	resultBuilder.WriteString("🏆 **WINNERS:**\n")
-
 	playerWinnings := make(map[string]int)
 
-	for i, pot := range pots {
+	for _, pot := range pots {
 		if pot.Amount == 0 || len(pot.EligiblePlayers) == 0 {
 			continue
 		}
@@ -930,36 +1088,40 @@ func determinePokerWinners(s *discordgo.Session, g *PokerGame) {
 				amt += remainder
 			}
 			playerWinnings[w.UserID] += amt
-			// We don't credit DB yet, just aggregate
-		}
-
-		// Log specific pot (optional, simplified for user)
-		if len(pots) > 1 {
-			// Debug or verbose info could go here, but for now we aggregate
 		}
-		// To avoid "unused" error if I don't use i
-		_ = i
 	}
 
+	return playerWinnings
+}
+
+func determinePokerWinners(s *discordgo.Session, g *PokerGame) {
+	playerWinnings := splitPokerPots(g)
+
+	var resultBuilder strings.Builder
+	resultBuilder.WriteString("🏆 **WINNERS:**\n")
+
 	// Apply winnings and build result string
 	for _, p := range g.Players {
 		win := playerWinnings[p.UserID]
 		if win > 0 {
-			DB.AddBalance(p.UserID, win)
+			creditPokerPlayer(g, p, win)
 			playerWinnings[p.UserID] = 0 // Clear to mark as processed
 
 			eval := EvaluateBestHand(append(p.HoleCards, g.CommunityCards...))
 			resultBuilder.WriteString(fmt.Sprintf("- **%s** won **$%d** with **%s**\n", p.Username, win, eval.Rank))
+			logPokerAction(g, "%s wins $%d with %s (%s).", p.Username, win, eval.Rank, formatHand(p.HoleCards))
 		}
 
 		// Refund remaining stack (this logic was in original, unclear why stack is refunded? Ah, stack is money *remaining* that wasn't bet. Yes, that stays with user implicitly if we didn't deduct it. Wait, the code deducts buyin at start. So `Stack` is money ON THE TABLE. So yes, we must refund `p.Stack`.
 
 		if p.Stack > 0 {
-			DB.AddBalance(p.UserID, p.Stack)
+			creditPokerPlayer(g, p, p.Stack)
 		}
 	}
 
-	g.ResultText = resultBuilder.String()
+	g.ResultText = resultBuilder.String() + playMoneyNote(g) + fairDealReveal(g.Fair)
+	logPokerAction(g, "Hand complete.")
+	recordPokerSnapshot(g)
 
 	// Show Play Again
 	components := createPokerPlayAgainButtons()
@@ -996,14 +1158,16 @@ func finishPokerGame(s *discordgo.Session, g *PokerGame, winner *PokerPlayer) {
 		totalPot += p.TotalBet
 	}
 
-	DB.AddBalance(winner.UserID, totalPot)
-	g.ResultText = fmt.Sprintf("🏆 **WINNER**: %s (+$%d)\nEveryone else folded.", winner.Username, totalPot)
+	creditPokerPlayer(g, winner, totalPot)
+	g.ResultText = fmt.Sprintf("🏆 **WINNER**: %s (+$%d)\nEveryone else folded.", winner.Username, totalPot) + playMoneyNote(g) + fairDealReveal(g.Fair)
+	logPokerAction(g, "%s wins $%d uncontested.", winner.Username, totalPot)
 	g.Stage = StageShowdown
+	recordPokerSnapshot(g)
 
 	// Refund all players
 	for _, p := range g.Players {
 		if p.Stack > 0 {
-			DB.AddBalance(p.UserID, p.Stack)
+			creditPokerPlayer(g, p, p.Stack)
 		}
 	}
 
@@ -1049,8 +1213,11 @@ func GetCardValue(v string) int {
 	case "10":
 		return 10
 	default:
-		val, _ := strconv.Atoi(v)
-		return val
+		// Only single digits are pip cards; anything else isn't a card at all
+		if len(v) == 1 && v[0] >= '2' && v[0] <= '9' {
+			return int(v[0] - '0')
+		}
+		return 0
 	}
 }
 
@@ -0,0 +1,366 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// PokerBotLevel is the difficulty of a computer-controlled poker seat.
+type PokerBotLevel string
+
+const (
+	BotEasy   PokerBotLevel = "easy"
+	BotMedium PokerBotLevel = "medium"
+	BotHard   PokerBotLevel = "hard"
+)
+
+// pokerBotProfile tunes how a difficulty level plays.
+type pokerBotProfile struct {
+	Label       string
+	Noise       float64 // Random jitter applied to the hand strength estimate
+	Bluff       float64 // Chance to raise regardless of hand strength
+	CallMargin  float64 // Extra strength required over the pot odds before calling
+	RaiseAt     float64 // Strength above which the bot raises
+	UsePotOdds  bool    // Easy bots ignore the price and play their cards
+	UsePosition bool    // Loosen up when acting late in the round
+	Simulations int     // Monte Carlo rollouts for hand strength (0 = heuristic only)
+}
+
+var pokerBotProfiles = map[PokerBotLevel]pokerBotProfile{
+	BotEasy:   {Label: "Easy", Noise: 0.25, Bluff: 0.05, CallMargin: 0, RaiseAt: 0.85, UsePotOdds: false},
+	BotMedium: {Label: "Medium", Noise: 0.10, Bluff: 0.05, CallMargin: 0.08, RaiseAt: 0.75, UsePotOdds: true, UsePosition: true},
+	BotHard:   {Label: "Hard", Noise: 0.04, Bluff: 0.08, CallMargin: 0.03, RaiseAt: 0.70, UsePotOdds: true, UsePosition: true, Simulations: 300},
+}
+
+var pokerBotDelay = 1500 * time.Millisecond
+
+func handlePokerAddBot(s *discordgo.Session, i *discordgo.InteractionCreate, g *PokerGame) {
+	pokerMutex.Lock()
+	defer pokerMutex.Unlock()
+
+	if i.Member.User.ID != g.HostID {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: "Only the host can add bots!", Flags: discordgo.MessageFlagsEphemeral},
+		})
+		return
+	}
+
+	if g.Stage != StageLobby {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: "Game already started!", Flags: discordgo.MessageFlagsEphemeral},
+		})
+		return
+	}
+
+	if len(g.Players) >= maxPokerPlayers {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: "Lobby is full!", Flags: discordgo.MessageFlagsEphemeral},
+		})
+		return
+	}
+
+	values := i.MessageComponentData().Values
+	if len(values) == 0 {
+		return
+	}
+	level := PokerBotLevel(values[0])
+	profile, ok := pokerBotProfiles[level]
+	if !ok {
+		return
+	}
+
+	botCount := 0
+	for _, p := range g.Players {
+		if p.IsBot {
+			botCount++
+		}
+	}
+
+	if !g.PlayMoney {
+		// Bots have no balance to lose, so the table switches to play money and the humans get their buy-ins back
+		for _, p := range g.Players {
+			creditPokerPlayer(g, p, p.Stack)
+		}
+		g.PlayMoney = true
+		log.Printf("[POKER BOT] Game %s is now play money", g.MessageID)
+	}
+
+	// Bots sit with the same stack as the host
+	g.Players = append(g.Players, &PokerPlayer{
+		UserID:   fmt.Sprintf("bot-%s-%d", g.MessageID, botCount+1),
+		Username: fmt.Sprintf("Bot %d (%s)", botCount+1, profile.Label),
+		Stack:    g.Players[0].Stack,
+		Status:   PlayerPlaying,
+		IsBot:    true,
+		BotLevel: level,
+	})
+	log.Printf("[POKER BOT] %s bot added to game %s", profile.Label, g.MessageID)
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{createPokerLobbyEmbed(g)},
+			Components: createPokerLobbyButtons(),
+		},
+	})
+}
+
+// schedulePokerBotTurn queues an automatic action if the current seat belongs to a bot. Callers must hold pokerMutex.
+func schedulePokerBotTurn(s *discordgo.Session, g *PokerGame) {
+	if g.Stage == StageLobby || g.Stage == StageShowdown {
+		return
+	}
+	bot := g.Players[g.CurrentTurn]
+	if !bot.IsBot {
+		return
+	}
+
+	if g.BotTimer != nil {
+		g.BotTimer.Stop()
+	}
+	g.BotTimer = time.AfterFunc(pokerBotDelay, func() {
+		pokerMutex.Lock()
+		defer pokerMutex.Unlock()
+
+		// The hand may have ended or moved on while we waited
+		if current, exists := activePokerGames[g.MessageID]; !exists || current != g {
+			return
+		}
+		if g.Stage == StageLobby || g.Stage == StageShowdown || g.Players[g.CurrentTurn] != bot {
+			return
+		}
+		runPokerBotTurn(s, g, bot)
+	})
+}
+
+// runPokerBotTurn decides and applies a bot's action, then advances the game. Callers must hold pokerMutex.
+func runPokerBotTurn(s *discordgo.Session, g *PokerGame, bot *PokerPlayer) {
+	profile := pokerBotProfiles[bot.BotLevel]
+	toCall := g.MinBet - bot.CurrentBet
+
+	pot := 0
+	opponents := 0
+	for _, p := range g.Players {
+		pot += p.TotalBet
+		if p != bot && p.Status != PlayerFolded {
+			opponents++
+		}
+	}
+
+	strength := estimatePokerHandStrength(bot.HoleCards, g.CommunityCards, opponents, profile.Simulations)
+	strength += (rand.Float64()*2 - 1) * profile.Noise
+
+	// Pot odds: the share of the final pot we have to put in to continue
+	threshold := 0.35
+	if profile.UsePotOdds {
+		threshold = 0.0
+		if toCall > 0 {
+			threshold = float64(toCall) / float64(pot+toCall)
+		}
+	}
+	threshold += profile.CallMargin
+	if profile.UsePosition {
+		threshold -= 0.08 * pokerSeatPosition(g, g.CurrentTurn)
+	}
+
+	bluffing := rand.Float64() < profile.Bluff
+	canRaise := bot.Status == PlayerPlaying && bot.Stack > toCall
+
+	switch {
+	case canRaise && (strength >= profile.RaiseAt || bluffing):
+		step := g.LastRaise
+		if step < g.BigBlind {
+			step = g.BigBlind
+		}
+		multiplier := 1
+		if strength > 0.9 {
+			multiplier = 3
+		} else if strength > profile.RaiseAt+0.1 {
+			multiplier = 2
+		}
+		target := g.MinBet + step*multiplier
+		applyPokerRaise(g, bot, target)
+		logPokerAction(g, "%s raises to $%d.", bot.Username, bot.CurrentBet)
+		log.Printf("[POKER ACTION] %s RAISED to %d (bot, strength %.2f)", bot.Username, bot.CurrentBet, strength)
+	case toCall <= 0 || bot.Status == PlayerAllIn || strength >= threshold:
+		callAmount := applyPokerCall(g, bot)
+		logPokerCall(g, bot, callAmount)
+		action := "CALL"
+		if callAmount == 0 {
+			action = "CHECK"
+		}
+		log.Printf("[POKER ACTION] %s %s | Amount: %d (bot, strength %.2f)", bot.Username, action, callAmount, strength)
+	default:
+		bot.Status = PlayerFolded
+		logPokerAction(g, "%s folds.", bot.Username)
+		log.Printf("[POKER ACTION] %s FOLDED (bot, strength %.2f)", bot.Username, strength)
+	}
+
+	nextPokerTurn(s, g, nil)
+}
+
+// pokerSeatPosition returns how late a seat acts relative to the dealer button, from 0 (first) to 1 (on the button).
+func pokerSeatPosition(g *PokerGame, seat int) float64 {
+	n := len(g.Players)
+	if n < 2 {
+		return 0
+	}
+	offset := (seat - g.DealerButton - 1 + n) % n
+	return float64(offset) / float64(n-1)
+}
+
+// estimatePokerHandStrength returns a 0-1 estimate of how good a hand is. With simulations > 0 it rolls out the rest of the board against random opponent hands and returns the share of pots won.
+func estimatePokerHandStrength(hole, community []Card, opponents, simulations int) float64 {
+	if simulations > 0 && opponents > 0 {
+		return simulatePokerEquity(hole, community, opponents, simulations)
+	}
+	if len(community) == 0 {
+		return preflopStrength(hole)
+	}
+	return madeHandStrength(hole, community)
+}
+
+// preflopStrength scores two hole cards with a simplified Chen formula, normalised to 0-1.
+func preflopStrength(hole []Card) float64 {
+	if len(hole) != 2 {
+		return 0
+	}
+	high, low := GetCardValue(hole[0].Value), GetCardValue(hole[1].Value)
+	if low > high {
+		high, low = low, high
+	}
+
+	chen := func(v int) float64 {
+		switch v {
+		case 14:
+			return 10
+		case 13:
+			return 8
+		case 12:
+			return 7
+		case 11:
+			return 6
+		default:
+			return float64(v) / 2
+		}
+	}
+
+	score := chen(high)
+	if high == low {
+		score *= 2
+		if score < 5 {
+			score = 5
+		}
+	} else {
+		if hole[0].Suit == hole[1].Suit {
+			score += 2
+		}
+		switch gap := high - low - 1; {
+		case gap == 1:
+			score--
+		case gap == 2:
+			score -= 2
+		case gap == 3:
+			score -= 4
+		case gap >= 4:
+			score -= 5
+		}
+		if high-low <= 2 && high < 12 {
+			score++
+		}
+	}
+
+	strength := score / 20
+	if strength < 0 {
+		strength = 0
+	}
+	if strength > 1 {
+		strength = 1
+	}
+	return strength
+}
+
+// madeHandStrength maps the best made hand to a 0-1 score, discounting hands that are entirely on the board.
+func madeHandStrength(hole, community []Card) float64 {
+	base := map[HandRank]float64{
+		RankHighCard:      0.10,
+		RankPair:          0.35,
+		RankTwoPair:       0.60,
+		RankThreeOfAKind:  0.72,
+		RankStraight:      0.80,
+		RankFlush:         0.85,
+		RankFullHouse:     0.92,
+		RankFourOfAKind:   0.97,
+		RankStraightFlush: 0.99,
+		RankRoyalFlush:    1.00,
+	}
+
+	all := append(append([]Card{}, hole...), community...)
+	best := EvaluateBestHand(all)
+	strength := base[best.Rank]
+
+	// Reward a high top card within the rank (e.g. top pair over bottom pair)
+	if len(best.Cards) > 0 {
+		strength += float64(GetCardValue(best.Cards[0].Value)-2) / 12 * 0.05
+	}
+
+	// Everyone shares a hand that lives on the board
+	if len(community) >= 5 {
+		if board := EvaluateBestHand(community); board.Score >= best.Score {
+			strength = base[RankHighCard]
+		}
+	}
+	return strength
+}
+
+// simulatePokerEquity estimates the share of the pot the hand wins against random opponent holdings.
+func simulatePokerEquity(hole, community []Card, opponents, simulations int) float64 {
+	known := make(map[Card]bool)
+	for _, c := range hole {
+		known[c] = true
+	}
+	for _, c := range community {
+		known[c] = true
+	}
+	var remaining []Card
+	for _, c := range NewOrderedDeck() {
+		if !known[c] {
+			remaining = append(remaining, c)
+		}
+	}
+
+	needed := 5 - len(community)
+	total := 0.0
+	for n := 0; n < simulations; n++ {
+		rand.Shuffle(len(remaining), func(i, j int) { remaining[i], remaining[j] = remaining[j], remaining[i] })
+		draw := remaining
+
+		board := append(append([]Card{}, community...), draw[:needed]...)
+		draw = draw[needed:]
+
+		mine := EvaluateBestHand(append(append([]Card{}, hole...), board...)).Score
+		best := mine
+		ties := 1
+		for o := 0; o < opponents; o++ {
+			theirs := EvaluateBestHand(append([]Card{draw[0], draw[1]}, board...)).Score
+			draw = draw[2:]
+			if theirs > best {
+				best = theirs
+				ties = 0
+			} else if theirs == best && best == mine {
+				ties++
+			}
+		}
+		if best == mine && ties > 0 {
+			total += 1 / float64(ties)
+		}
+	}
+	return total / float64(simulations)
+}
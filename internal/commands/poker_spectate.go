@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// PokerSpectatorDelay is how far behind the live table the delayed (hole cards visible) view runs. Zero disables it. Set from config in main.go.
+var PokerSpectatorDelay = 60 * time.Second
+
+var (
+	pokerSpectatorRefresh = 5 * time.Second
+	// Interaction tokens expire after 15 minutes, after which ephemeral messages can no longer be edited
+	pokerSpectatorTTL = 14 * time.Minute
+	maxPokerActionLog = 12
+)
+
+// pokerSnapshot is a full-information rendering of the table at a point in time, used for the delayed view.
+type pokerSnapshot struct {
+	At    time.Time
+	Embed *discordgo.MessageEmbed
+}
+
+type pokerSpectator struct {
+	Interaction *discordgo.Interaction
+	Delayed     bool
+	Joined      time.Time
+}
+
+func createPokerSpectateButtons() discordgo.ActionsRow {
+	buttons := []discordgo.MessageComponent{
+		discordgo.Button{Label: "Spectate", Style: discordgo.SecondaryButton, CustomID: "game_poker_spectate", Emoji: &discordgo.ComponentEmoji{Name: "👀"}},
+	}
+	if PokerSpectatorDelay > 0 {
+		buttons = append(buttons, discordgo.Button{
+			Label:    fmt.Sprintf("Delayed View (%s)", PokerSpectatorDelay),
+			Style:    discordgo.SecondaryButton,
+			CustomID: "game_poker_spectate_delayed",
+			Emoji:    &discordgo.ComponentEmoji{Name: "📺"},
+		})
+	}
+	return discordgo.ActionsRow{Components: buttons}
+}
+
+// logPokerAction appends a line to the game's action log. Callers must hold pokerMutex.
+func logPokerAction(g *PokerGame, format string, args ...interface{}) {
+	line := fmt.Sprintf("`%s` %s", time.Now().UTC().Format("15:04:05"), fmt.Sprintf(format, args...))
+	g.ActionLog = append(g.ActionLog, line)
+	if len(g.ActionLog) > maxPokerActionLog {
+		g.ActionLog = g.ActionLog[len(g.ActionLog)-maxPokerActionLog:]
+	}
+}
+
+func logPokerCall(g *PokerGame, p *PokerPlayer, amount int) {
+	switch {
+	case amount == 0:
+		logPokerAction(g, "%s checks.", p.Username)
+	case p.Status == PlayerAllIn:
+		logPokerAction(g, "%s calls $%d and is all-in.", p.Username, amount)
+	default:
+		logPokerAction(g, "%s calls $%d.", p.Username, amount)
+	}
+}
+
+// buildPokerSpectatorEmbed renders the table for onlookers, with the action log appended.
+func buildPokerSpectatorEmbed(g *PokerGame, showHoleCards bool) *discordgo.MessageEmbed {
+	embed := buildPokerTableEmbed(g, showHoleCards)
+	embed.Title = "Poker - Spectating"
+	embed.Color = 0x5865F2
+
+	actions := "No actions yet."
+	if len(g.ActionLog) > 0 {
+		actions = strings.Join(g.ActionLog, "\n")
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  "Action Log",
+		Value: actions,
+	})
+	return embed
+}
+
+// recordPokerSnapshot stores the current full-information table so delayed spectators can be shown it later. Callers must hold pokerMutex.
+func recordPokerSnapshot(g *PokerGame) {
+	if PokerSpectatorDelay <= 0 {
+		return
+	}
+
+	now := time.Now()
+	embed := buildPokerSpectatorEmbed(g, true)
+	embed.Title = "Poker - Delayed View"
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Showing the table as of %s ago", PokerSpectatorDelay)}
+	g.Snapshots = append(g.Snapshots, pokerSnapshot{At: now, Embed: embed})
+
+	// Keep only the newest snapshot that is already past the delay, plus everything after it
+	cutoff := now.Add(-PokerSpectatorDelay)
+	keepFrom := 0
+	for idx, snap := range g.Snapshots {
+		if snap.At.Before(cutoff) {
+			keepFrom = idx
+		}
+	}
+	g.Snapshots = g.Snapshots[keepFrom:]
+}
+
+// delayedPokerView returns the newest snapshot that is at least PokerSpectatorDelay old. Callers must hold pokerMutex.
+func delayedPokerView(g *PokerGame) *discordgo.MessageEmbed {
+	cutoff := time.Now().Add(-PokerSpectatorDelay)
+	var view *discordgo.MessageEmbed
+	for _, snap := range g.Snapshots {
+		if snap.At.After(cutoff) {
+			break
+		}
+		view = snap.Embed
+	}
+
+	if view == nil {
+		wait := PokerSpectatorDelay
+		if len(g.Snapshots) > 0 {
+			wait = time.Until(g.Snapshots[0].At.Add(PokerSpectatorDelay)).Round(time.Second)
+		}
+		return &discordgo.MessageEmbed{
+			Title:       "Poker - Delayed View",
+			Description: fmt.Sprintf("The delayed feed starts in about %s.", wait),
+			Color:       0x5865F2,
+		}
+	}
+	return view
+}
+
+func handlePokerSpectate(s *discordgo.Session, i *discordgo.InteractionCreate, g *PokerGame, delayed bool) {
+	pokerMutex.Lock()
+	defer pokerMutex.Unlock()
+
+	if delayed && PokerSpectatorDelay <= 0 {
+		return
+	}
+
+	for _, p := range g.Players {
+		if p.UserID == i.Member.User.ID {
+			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Content: "You are playing in this game and can't spectate it.", Flags: discordgo.MessageFlagsEphemeral},
+			})
+			return
+		}
+	}
+
+	embed := buildPokerSpectatorEmbed(g, false)
+	if delayed {
+		embed = delayedPokerView(g)
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	if g.Spectators == nil {
+		g.Spectators = make(map[string]*pokerSpectator)
+	}
+	g.Spectators[i.Member.User.ID] = &pokerSpectator{
+		Interaction: i.Interaction,
+		Delayed:     delayed,
+		Joined:      time.Now(),
+	}
+	log.Printf("[POKER SPECTATE] %s is watching game %s (delayed: %v)", i.Member.User.Username, g.MessageID, delayed)
+
+	if !g.Refreshing {
+		g.Refreshing = true
+		go refreshPokerSpectators(s, g)
+	}
+}
+
+// refreshPokerSpectators periodically re-renders every spectator's ephemeral view until the game (and its delayed feed) is over.
+func refreshPokerSpectators(s *discordgo.Session, g *PokerGame) {
+	ticker := time.NewTicker(pokerSpectatorRefresh)
+	defer ticker.Stop()
+
+	type update struct {
+		interaction *discordgo.Interaction
+		embed       *discordgo.MessageEmbed
+	}
+
+	var finishedAt time.Time
+	for range ticker.C {
+		pokerMutex.Lock()
+		_, live := activePokerGames[g.MessageID]
+		if !live && finishedAt.IsZero() {
+			finishedAt = time.Now()
+		}
+
+		var updates []update
+		liveEmbed := buildPokerSpectatorEmbed(g, false)
+		for id, spec := range g.Spectators {
+			if time.Since(spec.Joined) > pokerSpectatorTTL {
+				delete(g.Spectators, id)
+				continue
+			}
+			embed := liveEmbed
+			if spec.Delayed {
+				embed = delayedPokerView(g)
+			}
+			updates = append(updates, update{interaction: spec.Interaction, embed: embed})
+		}
+
+		// Live viewers get one final refresh with the result; delayed viewers until the feed catches up
+		done := len(g.Spectators) == 0 || (!live && time.Since(finishedAt) > PokerSpectatorDelay)
+		if done {
+			g.Refreshing = false
+		}
+		pokerMutex.Unlock()
+
+		for _, u := range updates {
+			if _, err := s.InteractionResponseEdit(u.interaction, &discordgo.WebhookEdit{
+				Embeds: &[]*discordgo.MessageEmbed{u.embed},
+			}); err != nil {
+				log.Printf("[POKER SPECTATE] Failed to refresh view: %v", err)
+			}
+		}
+
+		if done {
+			return
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+// parseCards turns shorthand like "As Td 5c" into cards. T is ten; suits are s, h, d, c.
+func parseCards(t testing.TB, s string) []Card {
+	t.Helper()
+	suits := map[byte]string{'s': "♠", 'h': "♥", 'd': "♦", 'c': "♣"}
+	var cards []Card
+	for _, f := range strings.Fields(s) {
+		suit, ok := suits[f[len(f)-1]]
+		if !ok || len(f) != 2 {
+			t.Fatalf("bad card %q", f)
+		}
+		value := f[:1]
+		if value == "T" {
+			value = "10"
+		}
+		cards = append(cards, Card{Suit: suit, Value: value})
+	}
+	return cards
+}
+
+func TestGetCardValue(t *testing.T) {
+	tests := map[string]int{
+		"2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9,
+		"10": 10, "J": 11, "Q": 12, "K": 13, "A": 14,
+	}
+	for value, want := range tests {
+		if got := GetCardValue(value); got != want {
+			t.Errorf("GetCardValue(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
+
+func TestEvaluateFiveCardHand(t *testing.T) {
+	tests := []struct {
+		name string
+		hand string
+		want HandRank
+	}{
+		{"royal flush", "As Ks Qs Js Ts", RankRoyalFlush},
+		{"straight flush", "9h 8h 7h 6h 5h", RankStraightFlush},
+		{"steel wheel", "Ad 2d 3d 4d 5d", RankStraightFlush},
+		{"four of a kind", "9c 9d 9h 9s Kd", RankFourOfAKind},
+		{"full house", "3c 3d 3h Ks Kd", RankFullHouse},
+		{"flush", "Ah Jh 8h 4h 2h", RankFlush},
+		{"broadway straight", "Ac Kd Qh Js Tc", RankStraight},
+		{"wheel straight", "5c 4d 3h 2s Ac", RankStraight},
+		{"three of a kind", "7c 7d 7h Ks 2d", RankThreeOfAKind},
+		{"two pair", "Jc Jd 4h 4s Ad", RankTwoPair},
+		{"pair", "Tc Td 8h 4s 2d", RankPair},
+		{"high card", "Ac Jd 8h 4s 2d", RankHighCard},
+		{"no wrap-around straight", "Qc Kd Ah 2s 3d", RankHighCard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateFiveCardHand(parseCards(t, tt.hand))
+			if got.Rank != tt.want {
+				t.Errorf("rank = %s, want %s", got.Rank, tt.want)
+			}
+			if HandRank(got.Score>>20) != got.Rank {
+				t.Errorf("score %x does not encode rank %s", got.Score, got.Rank)
+			}
+		})
+	}
+}
+
+func TestEvaluateBestHandComparisons(t *testing.T) {
+	tests := []struct {
+		name          string
+		better, worse string
+	}{
+		{"wheel loses to six-high straight", "6c 5d 4h 3s 2c Kd Qh", "Ac 5d 4h 3s 2c Kd Qh"},
+		{"wheel beats trips", "Ac 5d 4h 3s 2c 2d 2h", "Kc Kd Kh 3s 2c 9d 7h"},
+		{"steel wheel loses to six-high straight flush", "6d 5d 4d 3d 2d Kc Qh", "Ad 5d 4d 3d 2d Kc Qh"},
+		{"pair kicker", "Ac Ad Kh 9s 7c 4d 2h", "Ac Ad Qh 9s 7c 4d 2h"},
+		{"pair third kicker", "Ac Ad Kh Qs 7c 4d 2h", "Ac Ad Kh Js 7c 4d 2h"},
+		{"two pair kicker", "Kc Kd 8h 8s Ac 4d 2h", "Kc Kd 8h 8s Qc 4d 2h"},
+		{"two pair top pair", "Kc Kd 3h 3s 5c 4d 2h", "Qc Qd Jh Js Ac 4d 2h"},
+		{"trips kicker", "9c 9d 9h As 7c 4d 2h", "9c 9d 9h Ks 7c 4d 2h"},
+		{"quads kicker", "5c 5d 5h 5s Ac 4d 2h", "5c 5d 5h 5s Kc 4d 2h"},
+		{"full house trips first", "3c 3d 3h 2s 2c Kd 9h", "2c 2d 2h As Ac Kd 9h"},
+		{"flush fifth card", "Ah Jh 8h 4h 3h Kd 2c", "Ah Jh 8h 4h 2h Kd 3c"},
+		{"high card last kicker", "Ac Jd 8h 4s 3d", "Ac Jd 8h 4s 2d"},
+		{"six cards picks best five", "Ac Ad As Kh Kd 2c", "Ac Ad As Qh Qd 2c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better := EvaluateBestHand(parseCards(t, tt.better))
+			worse := EvaluateBestHand(parseCards(t, tt.worse))
+			if better.Score <= worse.Score {
+				t.Errorf("%s (%s, %x) should beat %s (%s, %x)", tt.better, better.Rank, better.Score, tt.worse, worse.Rank, worse.Score)
+			}
+		})
+	}
+}
+
+func TestEvaluateBestHandTies(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"board plays", "2c 3d As Ks Qs Js Ts", "4c 5d As Ks Qs Js Ts"},
+		{"suits don't matter", "Ac Kd 9h 7s 3c", "Ad Kh 9s 7c 3d"},
+		{"kicker beyond five cards ignored", "Ac Ad Kh Qs Jc 3d 2h", "Ah As Kc Qd Js 4c 3s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := EvaluateBestHand(parseCards(t, tt.a))
+			b := EvaluateBestHand(parseCards(t, tt.b))
+			if a.Score != b.Score {
+				t.Errorf("%s (%x) and %s (%x) should tie", tt.a, a.Score, tt.b, b.Score)
+			}
+		})
+	}
+}
+
+type testSeat struct {
+	hole   string
+	bet    int
+	folded bool
+}
+
+// newTestPokerGame seats players with the given hole cards and total bets around a fixed board.
+func newTestPokerGame(t *testing.T, board string, button int, seats ...testSeat) *PokerGame {
+	t.Helper()
+	g := &PokerGame{CommunityCards: parseCards(t, board), DealerButton: button}
+	for idx, seat := range seats {
+		p := &PokerPlayer{
+			UserID:    string(rune('a' + idx)),
+			Username:  string(rune('A' + idx)),
+			HoleCards: parseCards(t, seat.hole),
+			TotalBet:  seat.bet,
+			Status:    PlayerPlaying,
+		}
+		if seat.folded {
+			p.Status = PlayerFolded
+		}
+		g.Players = append(g.Players, p)
+	}
+	return g
+}
+
+func TestSplitPokerPots(t *testing.T) {
+	tests := []struct {
+		name   string
+		board  string
+		button int
+		seats  []testSeat
+		want   map[string]int
+	}{
+		{
+			name:  "single winner takes all",
+			board: "2c 7d 9h Js Kd",
+			seats: []testSeat{{"Ac Ad", 100, false}, {"Qc Qd", 100, false}, {"3c 4d", 50, true}},
+			want:  map[string]int{"a": 250},
+		},
+		{
+			name:  "even split",
+			board: "As Ks Qs Js Ts",
+			seats: []testSeat{{"2c 3d", 100, false}, {"4c 5d", 100, false}},
+			want:  map[string]int{"a": 100, "b": 100},
+		},
+		{
+			name:  "odd chip goes to the first winner by seat",
+			board: "As Ks Qs Js Ts",
+			seats: []testSeat{{"2c 3d", 100, false}, {"4c 5d", 100, false}, {"6c 7d", 100, false}, {"8c 9d", 1, true}},
+			want:  map[string]int{"a": 101, "b": 100, "c": 100},
+		},
+		{
+			name:  "short all-in wins main pot only",
+			board: "2c 7d 9h Js 4s",
+			seats: []testSeat{{"Ac Ad", 50, false}, {"Kc Kd", 200, false}, {"Qc Qd", 200, false}},
+			want:  map[string]int{"a": 150, "b": 300},
+		},
+		{
+			name:  "split side pot with remainder",
+			board: "2c 7d 9h Js 4s",
+			seats: []testSeat{
+				{"Ac Ad", 10, false},
+				{"Kc 3d", 35, false},
+				{"Kd 3h", 35, false},
+				{"5c 6c", 35, true},
+			},
+			// Main pot 40 to A; side pot 75 split between B and C, odd chip to B (first by seat)
+			want: map[string]int{"a": 40, "b": 38, "c": 37},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestPokerGame(t, tt.board, tt.button, tt.seats...)
+			got := splitPokerPots(g)
+
+			total, paid := 0, 0
+			for _, p := range g.Players {
+				total += p.TotalBet
+				paid += got[p.UserID]
+				if got[p.UserID] != tt.want[p.UserID] {
+					t.Errorf("%s won %d, want %d", p.UserID, got[p.UserID], tt.want[p.UserID])
+				}
+			}
+			if paid != total {
+				t.Errorf("paid out %d of a %d pot", paid, total)
+			}
+		})
+	}
+}
+
+func TestDealPokerHand(t *testing.T) {
+	deck := NewOrderedDeck()
+
+	g := newTestPokerGame(t, "", 0, testSeat{}, testSeat{}, testSeat{})
+	g.SmallBlind, g.BigBlind = 5, 10
+	for _, p := range g.Players {
+		p.Stack = 100
+	}
+	dealPokerHand(g, append([]Card{}, deck...), 1)
+
+	for idx, p := range g.Players {
+		want := deck[idx*2 : idx*2+2]
+		if len(p.HoleCards) != 2 || p.HoleCards[0] != want[0] || p.HoleCards[1] != want[1] {
+			t.Errorf("seat %d got %v, want %v", idx, p.HoleCards, want)
+		}
+	}
+	if len(g.Deck) != 52-6 {
+		t.Errorf("deck has %d cards left, want 46", len(g.Deck))
+	}
+	if g.Players[2].CurrentBet != 5 || g.Players[0].CurrentBet != 10 || g.Players[1].CurrentBet != 0 {
+		t.Errorf("blinds posted as %d/%d/%d, want 10/0/5", g.Players[0].CurrentBet, g.Players[1].CurrentBet, g.Players[2].CurrentBet)
+	}
+	if g.CurrentTurn != 1 {
+		t.Errorf("first to act is seat %d, want 1", g.CurrentTurn)
+	}
+}
+
+func TestDealPokerHandHeadsUp(t *testing.T) {
+	g := newTestPokerGame(t, "", 0, testSeat{}, testSeat{})
+	g.SmallBlind, g.BigBlind = 5, 10
+	g.Players[0].Stack, g.Players[1].Stack = 100, 3
+	dealPokerHand(g, NewOrderedDeck(), 0)
+
+	// The button posts the small blind heads-up; a short stack goes all-in for less
+	if g.Players[0].CurrentBet != 5 {
+		t.Errorf("button posted %d, want 5", g.Players[0].CurrentBet)
+	}
+	if g.Players[1].CurrentBet != 3 || g.Players[1].Status != PlayerAllIn {
+		t.Errorf("short big blind posted %d (%v), want 3 all-in", g.Players[1].CurrentBet, g.Players[1].Status)
+	}
+}
+
+func TestCreditPokerPlayerPlayMoney(t *testing.T) {
+	// DB is nil in tests, so any payout that reached it would panic
+	g := newTestPokerGame(t, "", 0, testSeat{}, testSeat{})
+	g.PlayMoney = true
+	creditPokerPlayer(g, g.Players[0], 500)
+
+	g.PlayMoney = false
+	g.Players[1].IsBot = true
+	creditPokerPlayer(g, g.Players[1], 500)
+	creditPokerPlayer(g, g.Players[0], 0)
+}
+
+// fuzzCards picks n distinct cards from the ordered deck using the fuzzer's bytes.
+func fuzzCards(data []byte, n int) []Card {
+	deck := NewOrderedDeck()
+	var cards []Card
+	for _, b := range data {
+		if len(cards) == n {
+			break
+		}
+		idx := int(b) % len(deck)
+		cards = append(cards, deck[idx])
+		deck = append(deck[:idx], deck[idx+1:]...)
+	}
+	return cards
+}
+
+func FuzzEvaluateBestHand(f *testing.F) {
+	f.Add([]byte{12, 0, 1, 2, 3, 20, 40})
+	f.Add([]byte{8, 9, 10, 11, 12, 0, 0})
+	f.Add([]byte{0, 13, 26, 39, 1, 14, 27})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		cards := fuzzCards(data, 7)
+		if len(cards) < 5 {
+			return
+		}
+
+		best := EvaluateBestHand(append([]Card{}, cards...))
+		if best.Rank < RankHighCard || best.Rank > RankRoyalFlush {
+			t.Fatalf("rank %d out of range for %v", best.Rank, cards)
+		}
+		if HandRank(best.Score>>20) != best.Rank {
+			t.Fatalf("score %x does not encode rank %s", best.Score, best.Rank)
+		}
+		if len(best.Cards) != 5 {
+			t.Fatalf("best hand has %d cards", len(best.Cards))
+		}
+		if again := evaluateFiveCardHand(append([]Card{}, best.Cards...)); again.Score != best.Score {
+			t.Fatalf("re-evaluating %v gives %x, want %x", best.Cards, again.Score, best.Score)
+		}
+
+		// The result must not depend on the order the cards were given in
+		reversed := make([]Card, len(cards))
+		for idx, c := range cards {
+			reversed[len(cards)-1-idx] = c
+		}
+		if other := EvaluateBestHand(reversed); other.Score != best.Score {
+			t.Fatalf("%v scored %x reversed, %x forwards", cards, other.Score, best.Score)
+		}
+
+		// Adding a card can never make the best hand worse
+		if len(cards) > 5 {
+			if fewer := EvaluateBestHand(append([]Card{}, cards[:len(cards)-1]...)); fewer.Score > best.Score {
+				t.Fatalf("%v scored %x but its first %d cards scored %x", cards, best.Score, len(cards)-1, fewer.Score)
+			}
+		}
+	})
+}
+
+func FuzzEvaluateFiveCardHand(f *testing.F) {
+	f.Add([]byte{12, 0, 1, 2, 3}, uint8(2))
+	f.Add([]byte{8, 9, 10, 11, 12}, uint8(4))
+	f.Add([]byte{0, 13, 26, 39, 1}, uint8(1))
+	f.Fuzz(func(t *testing.T, data []byte, shift uint8) {
+		cards := fuzzCards(data, 5)
+		if len(cards) < 5 {
+			return
+		}
+
+		got := evaluateFiveCardHand(append([]Card{}, cards...))
+		if got.Rank < RankHighCard || got.Rank > RankRoyalFlush {
+			t.Fatalf("rank %d out of range for %v", got.Rank, cards)
+		}
+		if HandRank(got.Score>>20) != got.Rank {
+			t.Fatalf("score %x does not encode rank %s", got.Score, got.Rank)
+		}
+
+		// Any order of the same five cards scores the same
+		rotated := make([]Card, 5)
+		for idx, c := range cards {
+			rotated[(idx+int(shift))%5] = c
+		}
+		rotated[0], rotated[4] = rotated[4], rotated[0]
+		if other := evaluateFiveCardHand(rotated); other.Score != got.Score || other.Rank != got.Rank {
+			t.Fatalf("%v scored %x (%s) reordered as %v, %x (%s) as given", cards, other.Score, other.Rank, rotated, got.Score, got.Rank)
+		}
+	})
+}
+
+func FuzzGetCardValue(f *testing.F) {
+	for _, v := range []string{"A", "K", "Q", "J", "10", "9", "2", "1", "11", "-5", "+7", "", "Joker"} {
+		f.Add(v)
+	}
+	f.Fuzz(func(t *testing.T, v string) {
+		got := GetCardValue(v)
+		if got != 0 && (got < 2 || got > 14) {
+			t.Fatalf("GetCardValue(%q) = %d, want 0 or 2-14", v, got)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"soosa/internal/player"
+	"soosa/internal/subsonic"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var RadioCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "radio",
+		Description: "Listen to the internet radio stations on the music server",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "list",
+				Description: "Show the available stations",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "play",
+				Description: "Tune in to a station",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "name",
+						Description: "Station name",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+}
+
+func HandleRadioCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	if len(data.Options) == 0 {
+		return
+	}
+	sub := data.Options[0]
+
+	switch sub.Name {
+	case "list":
+		handleRadioList(s, i)
+	case "play":
+		handleRadioPlay(s, i, sub.Options[0].StringValue())
+	}
+}
+
+func handleRadioList(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	stations, err := PlayerManager.GetSubsonicClient().GetInternetRadioStations()
+	if err != nil {
+		respond(s, i, fmt.Sprintf("❌ Couldn't load stations: %v", err))
+		return
+	}
+	if len(stations) == 0 {
+		respond(s, i, "📭 The music server has no radio stations.")
+		return
+	}
+
+	var lines []string
+	for _, station := range stations {
+		line := fmt.Sprintf("📻 **%s**", station.Name)
+		if station.HomePageURL != "" {
+			line += fmt.Sprintf(" — [website](%s)", station.HomePageURL)
+		}
+		lines = append(lines, line)
+	}
+
+	respondEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       "📻 Radio Stations",
+		Description: strings.Join(lines, "\n"),
+		Color:       0x5865F2,
+		Footer:      &discordgo.MessageEmbedFooter{Text: "Use /radio play <name> to tune in"},
+	})
+}
+
+func handleRadioPlay(s *discordgo.Session, i *discordgo.InteractionCreate, name string) {
+	channelID := findUserVoiceChannel(s, i.GuildID, i.Member.User.ID)
+	if channelID == "" {
+		respond(s, i, "❌ You must be in a voice channel to play music.")
+		return
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+
+	stations, err := PlayerManager.GetSubsonicClient().GetInternetRadioStations()
+	if err != nil {
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: strPtr(fmt.Sprintf("❌ Couldn't load stations: %v", err)),
+		})
+		return
+	}
+	station := matchStation(stations, name)
+	if station == nil {
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: strPtr(fmt.Sprintf("❌ No station called **%s**. See /radio list.", name)),
+		})
+		return
+	}
+
+	song := stationSong(station)
+	playSongs(s, i, channelID, []*subsonic.Song{song}, fmt.Sprintf("📻 Added **%s** to the queue. Skip it to leave the station.", station.Name))
+}
+
+// matchStation picks the station called name, as matchByName does.
+func matchStation(stations []subsonic.InternetRadioStation, name string) *subsonic.InternetRadioStation {
+	return matchByName(stations, name, func(st *subsonic.InternetRadioStation) string { return st.Name })
+}
+
+// stationSong wraps a station so it can sit in the queue. It has no duration, and the player streams it from its own URL.
+func stationSong(station *subsonic.InternetRadioStation) *subsonic.Song {
+	return &subsonic.Song{
+		ID:     station.ID,
+		Title:  station.Name,
+		Artist: "Internet radio",
+		Source: player.NewRadioSource(station.StreamURL),
+	}
+}
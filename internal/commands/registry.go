@@ -27,18 +27,35 @@ var CommandPermissionMap = map[string]string{
 	"pause":      "music.pause",
 	"resume":     "music.resume",
 	"volume":     "music.volume",
+	"loop":       "music.loop",
+	"seek":       "music.seek",
+	"radio":      "music.radio",
+	// Links and local files reach beyond the music server, so /play url: and /play file: need their own node
+	"play.external": "music.play.external",
+	// Viewing the queue is harmless, but editing it affects everyone listening
+	"queue.edit": "music.queue.edit",
 
 	// Economy
 	"money": "economy.money",
 
 	// Games
-	"bj":     "games.bj",
-	"poker":  "games.poker",
-	"wordle": "games.wordle",
+	"bj":        "games.bj",
+	"poker":     "games.poker",
+	"wordle":    "games.wordle",
+	"trivia":    "games.trivia",
+	"roulette":  "games.roulette",
+	"slots":     "games.slots",
+	"tictactoe": "games.tictactoe",
+	"connect4":  "games.connect4",
+	"hangman":   "games.hangman",
+	"verify":    "games.verify",
 
 	// Permissions
-	"perm":        "admin.perm",
-	"admin.money": "admin.money",
+	"perm":         "admin.perm",
+	"casino":       "admin.casino",
+	"admin.money":  "admin.money",
+	"admin.wordle": "admin.wordle",
+	"admin.trivia": "admin.trivia",
 }
 
 // DB instance for permission checks
@@ -49,10 +66,18 @@ func AllCommands() []*discordgo.ApplicationCommand {
 	all := make([]*discordgo.ApplicationCommand, 0, len(ModerationCommands)+len(MusicCommands)+len(EconomyCommands)+len(BlackjackCommands)+1)
 	all = append(all, ModerationCommands...)
 	all = append(all, MusicCommands...)
+	all = append(all, RadioCommands...)
 	all = append(all, EconomyCommands...)
 	all = append(all, BlackjackCommands...)
 	all = append(all, PokerCommands...)
 	all = append(all, WordleCommands...)
+	all = append(all, TriviaCommands...)
+	all = append(all, RouletteCommands...)
+	all = append(all, SlotsCommands...)
+	all = append(all, CasinoCommands...)
+	all = append(all, BoardGameCommands...)
+	all = append(all, HangmanCommands...)
+	all = append(all, VerifyCommands...)
 	all = append(all, PermissionCommands...)
 	return all
 }
@@ -81,6 +106,24 @@ func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			HandlePokerComponent(s, i)
 		} else if strings.HasPrefix(id, "wordle_") {
 			HandleWordleComponent(s, i)
+		} else if strings.HasPrefix(id, "trivia_") {
+			HandleTriviaComponent(s, i)
+		} else if strings.HasPrefix(id, "roulette_") {
+			HandleRouletteComponent(s, i)
+		} else if strings.HasPrefix(id, "slots_") {
+			HandleSlotsComponent(s, i)
+		} else if strings.HasPrefix(id, "board_") {
+			HandleBoardGameComponent(s, i)
+		} else if strings.HasPrefix(id, "hangman_") {
+			HandleHangmanComponent(s, i)
+		}
+		return
+	}
+
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		switch i.ApplicationCommandData().Name {
+		case "play", "search":
+			HandleMusicAutocomplete(s, i)
 		}
 		return
 	}
@@ -89,6 +132,8 @@ func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		id := i.ModalSubmitData().CustomID
 		if strings.HasPrefix(id, "game_poker_") {
 			HandlePokerModal(s, i)
+		} else if strings.HasPrefix(id, "game_bj_") {
+			HandleBlackjackModal(s, i)
 		}
 		return
 	}
@@ -119,8 +164,10 @@ func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	case "kick", "ban", "timeout", "purge":
 		HandleModerationCommand(s, i, data)
 	// Music
-	case "play", "search", "queue", "skip", "stop", "nowplaying", "pause", "resume", "volume":
+	case "play", "search", "queue", "skip", "stop", "nowplaying", "pause", "resume", "volume", "loop", "seek":
 		HandleMusicCommand(s, i, data)
+	case "radio":
+		HandleRadioCommand(s, i, data)
 	// Economy
 	case "money":
 		HandleEconomyCommand(s, i, data)
@@ -131,6 +178,20 @@ func HandleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		HandlePokerCommand(s, i, data)
 	case "wordle":
 		HandleWordleCommand(s, i, data)
+	case "trivia":
+		HandleTriviaCommand(s, i, data)
+	case "roulette":
+		HandleRouletteCommand(s, i, data)
+	case "slots":
+		HandleSlotsCommand(s, i, data)
+	case "casino":
+		HandleCasinoCommand(s, i, data)
+	case "tictactoe", "connect4":
+		HandleBoardGameCommand(s, i, data)
+	case "hangman":
+		HandleHangmanCommand(s, i, data)
+	case "verify":
+		HandleVerifyCommand(s, i, data)
 	// Permissions
 	case "perm":
 		HandlePermissionCommand(s, i, data)
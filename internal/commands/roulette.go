@@ -0,0 +1,480 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var RouletteCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "roulette",
+		Description: "Bet on European roulette; everyone's bets in the channel ride on the same spin",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "bet",
+				Description: "What to bet on",
+				Required:    true,
+				Choices:     rouletteBetChoices(),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "amount",
+				Description: "Amount to bet",
+				Required:    true,
+				MinValue:    &[]float64{1}[0],
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "number",
+				Description: "The number, for single number bets",
+				Required:    false,
+				MinValue:    &[]float64{0}[0],
+				MaxValue:    36,
+			},
+		},
+	},
+}
+
+// rouletteBetGroup is a set of bets that cover the same count of numbers and share payout odds.
+type rouletteBetGroup struct {
+	Name  string
+	Label string
+	Size  int
+	Odds  int // default payout odds, e.g. 35 for 35:1
+}
+
+var rouletteBetGroups = []rouletteBetGroup{
+	{Name: "straight", Label: "Single number", Size: 1, Odds: 35},
+	{Name: "dozen", Label: "Dozens", Size: 12, Odds: 2},
+	{Name: "even", Label: "Red/black, odd/even, low/high", Size: 18, Odds: 1},
+}
+
+type rouletteBetKind struct {
+	Value string
+	Name  string
+	Group string
+}
+
+var rouletteBetKinds = []rouletteBetKind{
+	{Value: "number", Name: "Single number", Group: "straight"},
+	{Value: "red", Name: "Red", Group: "even"},
+	{Value: "black", Name: "Black", Group: "even"},
+	{Value: "odd", Name: "Odd", Group: "even"},
+	{Value: "even", Name: "Even", Group: "even"},
+	{Value: "low", Name: "Low (1-18)", Group: "even"},
+	{Value: "high", Name: "High (19-36)", Group: "even"},
+	{Value: "dozen1", Name: "1st dozen (1-12)", Group: "dozen"},
+	{Value: "dozen2", Name: "2nd dozen (13-24)", Group: "dozen"},
+	{Value: "dozen3", Name: "3rd dozen (25-36)", Group: "dozen"},
+}
+
+// rouletteRedNumbers are the red pockets on a European wheel; the rest apart from 0 are black.
+var rouletteRedNumbers = map[int]bool{
+	1: true, 3: true, 5: true, 7: true, 9: true, 12: true, 14: true, 16: true, 18: true,
+	19: true, 21: true, 23: true, 25: true, 27: true, 30: true, 32: true, 34: true, 36: true,
+}
+
+const (
+	roulettePockets   = 37
+	rouletteBetTime   = 30 * time.Second
+	maxRouletteBets   = 10 // per player per spin
+	rouletteBetsShown = 20
+)
+
+type RouletteBet struct {
+	UserID   string
+	Username string
+	Kind     string
+	Number   int
+	Amount   int
+}
+
+type RouletteTable struct {
+	MessageID  string
+	ChannelID  string
+	HostID     string
+	Bets       []*RouletteBet
+	SpinAt     time.Time
+	Timer      *time.Timer
+	Spun       bool
+	Payouts    map[string]int // odds by bet group, fixed when the table opens
+	Commission int
+}
+
+var (
+	// rouletteTables holds the open table for each channel.
+	rouletteTables = make(map[string]*RouletteTable)
+	rouletteMutex  sync.Mutex
+)
+
+func rouletteBetChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(rouletteBetKinds))
+	for _, k := range rouletteBetKinds {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: k.Name, Value: k.Value})
+	}
+	return choices
+}
+
+func getRouletteBetKind(value string) *rouletteBetKind {
+	for idx := range rouletteBetKinds {
+		if rouletteBetKinds[idx].Value == value {
+			return &rouletteBetKinds[idx]
+		}
+	}
+	return nil
+}
+
+func defaultRoulettePayouts() map[string]int {
+	payouts := make(map[string]int, len(rouletteBetGroups))
+	for _, g := range rouletteBetGroups {
+		payouts[g.Name] = g.Odds
+	}
+	return payouts
+}
+
+// rouletteOdds returns the odds for a bet group, falling back to the default for groups a guild never set.
+func rouletteOdds(payouts map[string]int, group string) int {
+	if odds, ok := payouts[group]; ok && odds > 0 {
+		return odds
+	}
+	for _, g := range rouletteBetGroups {
+		if g.Name == group {
+			return g.Odds
+		}
+	}
+	return 0
+}
+
+// rouletteHouseEdge is the share of every bet the house keeps on average for a bet covering size pockets.
+func rouletteHouseEdge(size, odds, commission int) float64 {
+	win := 1 + float64(odds)*float64(100-commission)/100
+	return 1 - float64(size)*win/roulettePockets
+}
+
+// rouletteColor returns "green", "red" or "black" for a pocket.
+func rouletteColor(n int) string {
+	switch {
+	case n == 0:
+		return "green"
+	case rouletteRedNumbers[n]:
+		return "red"
+	default:
+		return "black"
+	}
+}
+
+func formatRouletteNumber(n int) string {
+	switch rouletteColor(n) {
+	case "green":
+		return fmt.Sprintf("🟢 %d", n)
+	case "red":
+		return fmt.Sprintf("🔴 %d", n)
+	default:
+		return fmt.Sprintf("⚫ %d", n)
+	}
+}
+
+// rouletteBetWins reports whether a bet wins when the ball lands on result. Zero loses every bet except a straight bet on zero.
+func rouletteBetWins(kind string, number, result int) bool {
+	if kind == "number" {
+		return number == result
+	}
+	if result == 0 {
+		return false
+	}
+	switch kind {
+	case "red", "black":
+		return rouletteColor(result) == kind
+	case "odd":
+		return result%2 == 1
+	case "even":
+		return result%2 == 0
+	case "low":
+		return result <= 18
+	case "high":
+		return result >= 19
+	case "dozen1":
+		return result <= 12
+	case "dozen2":
+		return result >= 13 && result <= 24
+	case "dozen3":
+		return result >= 25
+	}
+	return false
+}
+
+// rouletteBetReturn is what a bet pays back on result, stake included, or 0 if it lost.
+func rouletteBetReturn(b *RouletteBet, result int, payouts map[string]int, commission int) int {
+	if !rouletteBetWins(b.Kind, b.Number, result) {
+		return 0
+	}
+	kind := getRouletteBetKind(b.Kind)
+	return b.Amount + afterCommission(b.Amount*rouletteOdds(payouts, kind.Group), commission)
+}
+
+func describeRouletteBet(b *RouletteBet) string {
+	if b.Kind == "number" {
+		return formatRouletteNumber(b.Number)
+	}
+	return getRouletteBetKind(b.Kind).Name
+}
+
+func HandleRouletteCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	bet := &RouletteBet{UserID: i.Member.User.ID, Username: i.Member.User.Username, Number: -1}
+	for _, opt := range data.Options {
+		switch opt.Name {
+		case "bet":
+			bet.Kind = opt.StringValue()
+		case "amount":
+			bet.Amount = int(opt.IntValue())
+		case "number":
+			bet.Number = int(opt.IntValue())
+		}
+	}
+
+	if getRouletteBetKind(bet.Kind) == nil {
+		respondError(s, i, "Unknown bet.")
+		return
+	}
+	if bet.Kind == "number" && bet.Number < 0 {
+		respondError(s, i, "Pick the number to bet on.")
+		return
+	}
+	if bet.Amount <= 0 {
+		respondError(s, i, "Bet must be positive.")
+		return
+	}
+
+	bal, err := DB.GetBalance(bet.UserID)
+	if err != nil {
+		respondError(s, i, "Database error.")
+		return
+	}
+	if bal < bet.Amount {
+		respondError(s, i, "Insufficient funds.")
+		return
+	}
+
+	rouletteMutex.Lock()
+	table, open := rouletteTables[i.ChannelID]
+	if open {
+		placed := 0
+		for _, b := range table.Bets {
+			if b.UserID == bet.UserID {
+				placed++
+			}
+		}
+		if placed >= maxRouletteBets {
+			rouletteMutex.Unlock()
+			respondError(s, i, fmt.Sprintf("You can place at most %d bets per spin.", maxRouletteBets))
+			return
+		}
+	} else {
+		settings := guildCasinoSettings(i.GuildID)
+		table = &RouletteTable{
+			ChannelID:  i.ChannelID,
+			HostID:     bet.UserID,
+			SpinAt:     time.Now().Add(rouletteBetTime),
+			Payouts:    settings.RoulettePayouts,
+			Commission: settings.RouletteCommission,
+		}
+		rouletteTables[i.ChannelID] = table
+	}
+
+	// Bets are held by the table until the spin
+	if err := DB.AddBalance(bet.UserID, -bet.Amount); err != nil {
+		if !open {
+			delete(rouletteTables, i.ChannelID)
+		}
+		rouletteMutex.Unlock()
+		respondError(s, i, "Transaction failed.")
+		return
+	}
+	table.Bets = append(table.Bets, bet)
+	embed := buildRouletteTableEmbed(table)
+	rouletteMutex.Unlock()
+
+	log.Printf("[ROULETTE BET] %s bet $%d on %s (%d) in %s", bet.Username, bet.Amount, bet.Kind, bet.Number, i.ChannelID)
+
+	if open {
+		respondSuccess(s, i, fmt.Sprintf("🎲 **%s** bet **$%d** on **%s**.", bet.Username, bet.Amount, describeRouletteBet(bet)))
+		rouletteMutex.Lock()
+		messageID := table.MessageID
+		rouletteMutex.Unlock()
+		if messageID != "" {
+			s.ChannelMessageEditEmbed(table.ChannelID, messageID, embed)
+		}
+		return
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: createRouletteTableButtons(),
+		},
+	})
+	if err != nil {
+		refundRouletteTable(table)
+		return
+	}
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		refundRouletteTable(table)
+		return
+	}
+
+	rouletteMutex.Lock()
+	table.MessageID = msg.ID
+	table.Timer = time.AfterFunc(rouletteBetTime, func() {
+		spinRouletteTable(s, table)
+	})
+	rouletteMutex.Unlock()
+}
+
+// refundRouletteTable closes a table that never got its message and gives everyone their bets back.
+func refundRouletteTable(t *RouletteTable) {
+	rouletteMutex.Lock()
+	defer rouletteMutex.Unlock()
+	if t.Spun {
+		return
+	}
+	t.Spun = true
+	if rouletteTables[t.ChannelID] == t {
+		delete(rouletteTables, t.ChannelID)
+	}
+	for _, b := range t.Bets {
+		DB.AddBalance(b.UserID, b.Amount)
+	}
+}
+
+func buildRouletteTableEmbed(t *RouletteTable) *discordgo.MessageEmbed {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Place your bets with `/roulette`. The wheel spins <t:%d:R>.\n\n", t.SpinAt.Unix()))
+	total := 0
+	for idx, b := range t.Bets {
+		total += b.Amount
+		if idx < rouletteBetsShown {
+			sb.WriteString(fmt.Sprintf("- **%s**: $%d on %s\n", b.Username, b.Amount, describeRouletteBet(b)))
+		}
+	}
+	if len(t.Bets) > rouletteBetsShown {
+		sb.WriteString(fmt.Sprintf("...and %d more\n", len(t.Bets)-rouletteBetsShown))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Roulette",
+		Description: sb.String(),
+		Color:       0x1abc9c,
+		Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("%d bets | $%d on the table", len(t.Bets), total)},
+	}
+}
+
+func createRouletteTableButtons() []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: "Spin Now", Style: discordgo.SuccessButton, CustomID: "roulette_spin"},
+			},
+		},
+	}
+}
+
+func HandleRouletteComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.MessageComponentData().CustomID != "roulette_spin" {
+		return
+	}
+
+	rouletteMutex.Lock()
+	table, exists := rouletteTables[i.ChannelID]
+	var problem string
+	switch {
+	case !exists || table.MessageID != i.Message.ID:
+		problem = "This table has already spun."
+	case table.HostID != i.Member.User.ID:
+		problem = "Only the player who opened the table can spin early!"
+	}
+	rouletteMutex.Unlock()
+
+	if problem != "" {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: problem, Flags: discordgo.MessageFlagsEphemeral},
+		})
+		return
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	spinRouletteTable(s, table)
+}
+
+// spinRouletteTable spins the wheel once for every bet on the table and pays the winners.
+func spinRouletteTable(s *discordgo.Session, t *RouletteTable) {
+	rouletteMutex.Lock()
+	if t.Spun {
+		rouletteMutex.Unlock()
+		return
+	}
+	t.Spun = true
+	if t.Timer != nil {
+		t.Timer.Stop()
+	}
+	if rouletteTables[t.ChannelID] == t {
+		delete(rouletteTables, t.ChannelID)
+	}
+	rouletteMutex.Unlock()
+
+	result := DefaultShuffler.Intn(roulettePockets)
+
+	// Net result per player, in the order they first bet
+	var order []string
+	names := make(map[string]string)
+	staked := make(map[string]int)
+	returned := make(map[string]int)
+	for _, b := range t.Bets {
+		if _, seen := names[b.UserID]; !seen {
+			order = append(order, b.UserID)
+			names[b.UserID] = b.Username
+		}
+		staked[b.UserID] += b.Amount
+		returned[b.UserID] += rouletteBetReturn(b, result, t.Payouts, t.Commission)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("The ball lands on **%s**!\n", formatRouletteNumber(result)))
+	for _, userID := range order {
+		if returned[userID] > 0 {
+			if err := DB.AddBalance(userID, returned[userID]); err != nil {
+				log.Printf("[ROULETTE] Failed to pay %s $%d: %v", userID, returned[userID], err)
+			}
+		}
+		switch net := returned[userID] - staked[userID]; {
+		case net > 0:
+			sb.WriteString(fmt.Sprintf("\n- %s: Won $%d 🎉", names[userID], net))
+		case net < 0:
+			sb.WriteString(fmt.Sprintf("\n- %s: Lost $%d ❌", names[userID], -net))
+		default:
+			sb.WriteString(fmt.Sprintf("\n- %s: Broke even 🤝", names[userID]))
+		}
+	}
+	log.Printf("[ROULETTE SPIN] Channel %s | Result: %d | Bets: %d", t.ChannelID, result, len(t.Bets))
+
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Channel: t.ChannelID,
+		ID:      t.MessageID,
+		Embed: &discordgo.MessageEmbed{
+			Title:       "Roulette - Results",
+			Description: sb.String(),
+			Color:       0x00FF00,
+		},
+		Components: &[]discordgo.MessageComponent{},
+	})
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRouletteBetWins(t *testing.T) {
+	tests := []struct {
+		kind   string
+		number int
+		result int
+		want   bool
+	}{
+		{"number", 17, 17, true},
+		{"number", 0, 0, true},
+		{"number", 17, 18, false},
+		{"red", -1, 1, true},
+		{"red", -1, 2, false},
+		{"black", -1, 2, true},
+		{"red", -1, 0, false},
+		{"black", -1, 0, false},
+		{"odd", -1, 35, true},
+		{"even", -1, 0, false},
+		{"even", -1, 36, true},
+		{"low", -1, 18, true},
+		{"high", -1, 18, false},
+		{"high", -1, 19, true},
+		{"dozen1", -1, 12, true},
+		{"dozen2", -1, 13, true},
+		{"dozen2", -1, 25, false},
+		{"dozen3", -1, 36, true},
+	}
+	for _, tt := range tests {
+		if got := rouletteBetWins(tt.kind, tt.number, tt.result); got != tt.want {
+			t.Errorf("rouletteBetWins(%s, %d, %d) = %v, want %v", tt.kind, tt.number, tt.result, got, tt.want)
+		}
+	}
+}
+
+func TestRouletteWheel(t *testing.T) {
+	red, black := 0, 0
+	for n := 1; n < roulettePockets; n++ {
+		if rouletteColor(n) == "red" {
+			red++
+		} else {
+			black++
+		}
+	}
+	if red != 18 || black != 18 || rouletteColor(0) != "green" {
+		t.Errorf("wheel has %d red and %d black pockets", red, black)
+	}
+
+	// Every bet kind should cover as many pockets as its group says
+	for _, kind := range rouletteBetKinds {
+		if kind.Value == "number" {
+			continue
+		}
+		covered := 0
+		for n := 0; n < roulettePockets; n++ {
+			if rouletteBetWins(kind.Value, -1, n) {
+				covered++
+			}
+		}
+		for _, g := range rouletteBetGroups {
+			if g.Name == kind.Group && g.Size != covered {
+				t.Errorf("%s covers %d pockets, group %s says %d", kind.Value, covered, g.Name, g.Size)
+			}
+		}
+	}
+}
+
+func TestRouletteBetReturn(t *testing.T) {
+	payouts := defaultRoulettePayouts()
+	tests := []struct {
+		name       string
+		bet        RouletteBet
+		result     int
+		commission int
+		want       int
+	}{
+		{"straight win", RouletteBet{Kind: "number", Number: 7, Amount: 10}, 7, 0, 360},
+		{"straight loss", RouletteBet{Kind: "number", Number: 7, Amount: 10}, 8, 0, 0},
+		{"even money", RouletteBet{Kind: "red", Amount: 10}, 1, 0, 20},
+		{"dozen", RouletteBet{Kind: "dozen3", Amount: 10}, 30, 0, 30},
+		{"commission", RouletteBet{Kind: "number", Number: 7, Amount: 10}, 7, 10, 325},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rouletteBetReturn(&tt.bet, tt.result, payouts, tt.commission); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouletteHouseEdge(t *testing.T) {
+	// Standard European odds all give the house 1/37
+	for _, g := range rouletteBetGroups {
+		if edge := rouletteHouseEdge(g.Size, g.Odds, 0); math.Abs(edge-1.0/37) > 1e-9 {
+			t.Errorf("%s edge = %f, want %f", g.Name, edge, 1.0/37)
+		}
+	}
+	if edge := rouletteHouseEdge(1, 36, 0); math.Abs(edge) > 1e-9 {
+		t.Errorf("36:1 on a single number should be a fair bet, got edge %f", edge)
+	}
+	if edge := rouletteHouseEdge(12, 3, 0); edge >= 0 {
+		t.Errorf("3:1 on a dozen should favour players, got edge %f", edge)
+	}
+	if rouletteOdds(map[string]int{"straight": 30}, "dozen") != 2 {
+		t.Error("missing groups should fall back to the default odds")
+	}
+}
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/big"
+	mrand "math/rand/v2"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Shuffler is a source of random indexes for dealing cards and picking words.
+type Shuffler interface {
+	// Intn returns a uniform random number in [0, n).
+	Intn(n int) int
+}
+
+// cryptoShuffler draws from the operating system's secure random source.
+type cryptoShuffler struct{}
+
+func (cryptoShuffler) Intn(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		// crypto/rand only fails if the OS source is broken, and dealing from a predictable deck is worse than crashing
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return int(v.Int64())
+}
+
+// seededShuffler wraps a deterministic math/rand/v2 generator.
+type seededShuffler struct {
+	rnd *mrand.Rand
+}
+
+func (s seededShuffler) Intn(n int) int {
+	return s.rnd.IntN(n)
+}
+
+// DefaultShuffler is used for every deal and random pick unless provably fair mode is on. Tests may swap in NewSeededShuffler.
+var DefaultShuffler Shuffler = cryptoShuffler{}
+
+// NewSeededShuffler returns a reproducible shuffler for tests and replays.
+func NewSeededShuffler(seed uint64) Shuffler {
+	return seededShuffler{rnd: mrand.New(mrand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+}
+
+// newChaChaShuffler returns the shuffler used by provably fair deals, fully determined by the 32-byte seed.
+func newChaChaShuffler(seed [32]byte) Shuffler {
+	return seededShuffler{rnd: mrand.New(mrand.NewChaCha8(seed))}
+}
+
+// ShuffleCards shuffles the deck in place with a Fisher-Yates shuffle driven by sh.
+func ShuffleCards(deck []Card, sh Shuffler) {
+	for i := len(deck) - 1; i > 0; i-- {
+		j := sh.Intn(i + 1)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+}
+
+// ProvablyFair makes poker and blackjack commit to a seed hash when the lobby opens and reveal the seed afterwards. Set from config in main.go.
+var ProvablyFair bool
+
+const (
+	maxClientSeedPart   = 64  // Longest seed a player can add in one go
+	maxClientSeed       = 512 // Longest the combined client seed can grow
+	clientSeedSeparator = ":" // Joins the seeds players add, and so can't appear in one
+)
+
+// FairDeal is a committed deck. The server seed's hash is shown as soon as the lobby opens, players can then mix in client seeds of their own, and the deck is shuffled from both. Once the hand is over the server seed is revealed, so anyone can rebuild the deck with /verify and see the house couldn't have picked it.
+type FairDeal struct {
+	Seed       [32]byte
+	Commitment string
+	ClientSeed string // What players added before the deal, joined with ":"
+}
+
+// NewFairDeal draws a fresh secret seed and commits to it.
+func NewFairDeal() (*FairDeal, error) {
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(seed[:])
+	return &FairDeal{Seed: seed, Commitment: hex.EncodeToString(hash[:])}, nil
+}
+
+// AddClientSeed mixes a player's seed into the deal. It fails for seeds containing the separator, and once the combined seed would grow too long.
+func (f *FairDeal) AddClientSeed(part string) error {
+	part = strings.TrimSpace(part)
+	if part == "" {
+		return fmt.Errorf("seed can't be empty")
+	}
+	if len(part) > maxClientSeedPart {
+		return fmt.Errorf("seed can be at most %d characters", maxClientSeedPart)
+	}
+	// Seeds are joined with ":", so one inside a seed would make "a:b" + "c" and "a" + "b:c" look the same
+	if strings.Contains(part, clientSeedSeparator) {
+		return fmt.Errorf("seed can't contain %q", clientSeedSeparator)
+	}
+	combined := part
+	if f.ClientSeed != "" {
+		combined = f.ClientSeed + clientSeedSeparator + part
+	}
+	if len(combined) > maxClientSeed {
+		return fmt.Errorf("this table already has enough seeds")
+	}
+	f.ClientSeed = combined
+	return nil
+}
+
+// SeedHex is the seed as revealed to players once the hand is over.
+func (f *FairDeal) SeedHex() string {
+	return hex.EncodeToString(f.Seed[:])
+}
+
+// Deck returns the 52 cards in the order this seed and the client seed deal them.
+func (f *FairDeal) Deck() []Card {
+	mac := hmac.New(sha256.New, f.Seed[:])
+	mac.Write([]byte(f.ClientSeed))
+	var deckSeed [32]byte
+	copy(deckSeed[:], mac.Sum(nil))
+
+	deck := NewOrderedDeck()
+	ShuffleCards(deck, newChaChaShuffler(deckSeed))
+	return deck
+}
+
+// VerifyFairDeal checks a revealed seed against the hash published before the hand and returns the deck it produces with the given client seed.
+func VerifyFairDeal(seedHex, commitment, clientSeed string) ([]Card, error) {
+	raw, err := hex.DecodeString(strings.TrimSpace(seedHex))
+	if err != nil || len(raw) != 32 {
+		return nil, fmt.Errorf("seed must be 64 hex characters")
+	}
+
+	deal := &FairDeal{ClientSeed: clientSeed}
+	copy(deal.Seed[:], raw)
+	hash := sha256.Sum256(deal.Seed[:])
+	if !strings.EqualFold(hex.EncodeToString(hash[:]), strings.TrimSpace(commitment)) {
+		return nil, fmt.Errorf("seed does not match the published hash")
+	}
+	return deal.Deck(), nil
+}
+
+// newFairCommitment commits to a seed for a new lobby when provably fair mode is on. It returns nil otherwise.
+func newFairCommitment() *FairDeal {
+	if !ProvablyFair {
+		return nil
+	}
+	fair, err := NewFairDeal()
+	if err != nil {
+		// Fall back to the normal secure shuffle; the hand just can't be verified
+		log.Printf("[FAIR] Failed to draw seed, dealing without a commitment: %v", err)
+		return nil
+	}
+	return fair
+}
+
+// gameDeck deals from the lobby's commitment, or shuffles normally when there isn't one.
+func gameDeck(f *FairDeal) []Card {
+	if f == nil {
+		return NewDeck()
+	}
+	return f.Deck()
+}
+
+// fairDealFooter shows the commitment while a hand is in play.
+func fairDealFooter(f *FairDeal) *discordgo.MessageEmbedFooter {
+	if f == nil {
+		return nil
+	}
+	return &discordgo.MessageEmbedFooter{Text: "Provably fair | Deck hash: " + f.Commitment}
+}
+
+// fairLobbyField shows a lobby the commitment and the client seed so far, so players know what they're adding to.
+func fairLobbyField(f *FairDeal) *discordgo.MessageEmbedField {
+	clientSeed := "None yet. Press **Add Seed** to mix in your own."
+	if f.ClientSeed != "" {
+		clientSeed = fmt.Sprintf("`%s`", f.ClientSeed)
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  "🔐 Provably fair",
+		Value: fmt.Sprintf("Deck hash: `%s`\nClient seed: %s", f.Commitment, clientSeed),
+	}
+}
+
+// fairDealReveal is appended to results so players can check the deal with /verify.
+func fairDealReveal(f *FairDeal) string {
+	if f == nil {
+		return ""
+	}
+	clientSeed := "(none)"
+	if f.ClientSeed != "" {
+		clientSeed = fmt.Sprintf("`%s`", f.ClientSeed)
+	}
+	return fmt.Sprintf("\n\n🔐 **Provably fair**\nHash: `%s`\nSeed: `%s`\nClient seed: %s\nCheck it with `/verify`.", f.Commitment, f.SeedHex(), clientSeed)
+}
+
+// fairSeedButton lets players add a client seed while a lobby is open. It is only shown in provably fair mode.
+func fairSeedButton(customID string) []discordgo.MessageComponent {
+	if !ProvablyFair {
+		return nil
+	}
+	return []discordgo.MessageComponent{
+		discordgo.Button{Label: "Add Seed", Style: discordgo.SecondaryButton, CustomID: customID, Emoji: &discordgo.ComponentEmoji{Name: "🎲"}},
+	}
+}
+
+// openFairSeedModal asks a player for the client seed they want to add.
+func openFairSeedModal(s *discordgo.Session, i *discordgo.InteractionCreate, customID string) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: customID,
+			Title:    "Add a Client Seed",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "seed",
+							Label:       "Any text you like",
+							Style:       discordgo.TextInputShort,
+							Placeholder: "lucky-7",
+							Required:    true,
+							MaxLength:   maxClientSeedPart,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// fairSeedModalValue reads the seed typed into openFairSeedModal.
+func fairSeedModalValue(i *discordgo.InteractionCreate) string {
+	for _, row := range i.ModalSubmitData().Components {
+		actions, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, c := range actions.Components {
+			if input, ok := c.(*discordgo.TextInput); ok && input.CustomID == "seed" {
+				return input.Value
+			}
+		}
+	}
+	return ""
+}
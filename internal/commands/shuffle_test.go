@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSeededShufflerIsReproducible(t *testing.T) {
+	a, b := NewOrderedDeck(), NewOrderedDeck()
+	ShuffleCards(a, NewSeededShuffler(42))
+	ShuffleCards(b, NewSeededShuffler(42))
+	for idx := range a {
+		if a[idx] != b[idx] {
+			t.Fatalf("same seed dealt %v and %v at position %d", a[idx], b[idx], idx)
+		}
+	}
+
+	c := NewOrderedDeck()
+	ShuffleCards(c, NewSeededShuffler(43))
+	same := true
+	for idx := range a {
+		if a[idx] != c[idx] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("different seeds dealt the same deck")
+	}
+}
+
+func TestShuffleCardsIsUniform(t *testing.T) {
+	// Each of the 6 orderings of three cards should come up about equally often
+	sh := NewSeededShuffler(7)
+	counts := make(map[string]int)
+	const rounds = 60000
+	for n := 0; n < rounds; n++ {
+		deck := []Card{{Value: "A"}, {Value: "B"}, {Value: "C"}}
+		ShuffleCards(deck, sh)
+		counts[deck[0].Value+deck[1].Value+deck[2].Value]++
+	}
+	if len(counts) != 6 {
+		t.Fatalf("saw %d orderings, want 6", len(counts))
+	}
+	for order, n := range counts {
+		if n < rounds/6*9/10 || n > rounds/6*11/10 {
+			t.Errorf("ordering %s came up %d times out of %d", order, n, rounds)
+		}
+	}
+}
+
+func TestFairDealVerifies(t *testing.T) {
+	fair, err := NewFairDeal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fair.AddClientSeed("lucky-7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fair.AddClientSeed(" second "); err != nil || fair.ClientSeed != "lucky-7:second" {
+		t.Fatalf("client seed = %q, %v", fair.ClientSeed, err)
+	}
+
+	deck, err := VerifyFairDeal(fair.SeedHex(), strings.ToUpper(fair.Commitment), fair.ClientSeed)
+	if err != nil {
+		t.Fatalf("verifying the real seed: %v", err)
+	}
+	want := fair.Deck()
+	for idx := range want {
+		if deck[idx] != want[idx] {
+			t.Fatalf("verified deck differs at position %d: %v vs %v", idx, deck[idx], want[idx])
+		}
+	}
+
+	// The client seed changes the deck, so the house can't choose it alone
+	serverOnly, _ := VerifyFairDeal(fair.SeedHex(), fair.Commitment, "")
+	same := true
+	for idx := range want {
+		if serverOnly[idx] != want[idx] {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Error("the client seed didn't change the deck")
+	}
+
+	other, _ := NewFairDeal()
+	if _, err := VerifyFairDeal(other.SeedHex(), fair.Commitment, fair.ClientSeed); err == nil {
+		t.Error("a different seed verified against the commitment")
+	}
+	if _, err := VerifyFairDeal("not hex", fair.Commitment, ""); err == nil {
+		t.Error("a malformed seed verified")
+	}
+}
+
+func TestAddClientSeedLimits(t *testing.T) {
+	fair := &FairDeal{}
+	if err := fair.AddClientSeed("   "); err == nil {
+		t.Error("an empty seed was accepted")
+	}
+	if err := fair.AddClientSeed(strings.Repeat("x", maxClientSeedPart+1)); err == nil {
+		t.Error("an overlong seed was accepted")
+	}
+	if err := fair.AddClientSeed("a:b"); err == nil {
+		t.Error("a seed containing the separator was accepted")
+	}
+	for n := 0; ; n++ {
+		if err := fair.AddClientSeed(strings.Repeat("y", maxClientSeedPart)); err != nil {
+			break
+		}
+		if n > maxClientSeed {
+			t.Fatal("client seed grew without limit")
+		}
+	}
+	if len(fair.ClientSeed) > maxClientSeed {
+		t.Errorf("client seed is %d characters, limit is %d", len(fair.ClientSeed), maxClientSeed)
+	}
+}
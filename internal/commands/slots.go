@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"soosa/internal/database"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var SlotsCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "slots",
+		Description: "Spin the slot machine",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "bet",
+				Description: "Amount to bet",
+				Required:    true,
+				MinValue:    &[]float64{1}[0],
+			},
+		},
+	},
+}
+
+const slotReels = 3
+
+// defaultSlotSymbols is the stock paytable; it pays back about 93% of every bet.
+func defaultSlotSymbols() []database.SlotSymbol {
+	return []database.SlotSymbol{
+		{Emoji: "🍒", Weight: 10, Three: 10, Two: 1},
+		{Emoji: "🍋", Weight: 8, Three: 15, Two: 2},
+		{Emoji: "🍊", Weight: 7, Three: 20, Two: 2},
+		{Emoji: "🍇", Weight: 5, Three: 30, Two: 2},
+		{Emoji: "🔔", Weight: 3, Three: 50, Two: 3},
+		{Emoji: "💎", Weight: 2, Three: 100, Two: 5},
+		{Emoji: "7️⃣", Weight: 1, Three: 500, Two: 10},
+	}
+}
+
+// validateSlotSymbols checks a paytable has a sensible number of distinct symbols, each of which can land.
+func validateSlotSymbols(symbols []database.SlotSymbol) error {
+	if len(symbols) < minSlotSymbols || len(symbols) > maxSlotSymbols {
+		return fmt.Errorf("the reels need between %d and %d symbols", minSlotSymbols, maxSlotSymbols)
+	}
+	seen := make(map[string]bool)
+	for _, sym := range symbols {
+		if sym.Emoji == "" || len(sym.Emoji) > 64 {
+			return fmt.Errorf("symbols must be a single emoji")
+		}
+		if seen[sym.Emoji] {
+			return fmt.Errorf("%s is on the reels twice", sym.Emoji)
+		}
+		seen[sym.Emoji] = true
+		if sym.Weight < 1 || sym.Weight > maxSlotWeight {
+			return fmt.Errorf("%s needs a weight between 1 and %d", sym.Emoji, maxSlotWeight)
+		}
+		if sym.Three < 0 || sym.Two < 0 || sym.Three > maxSlotMultiplier || sym.Two > maxSlotMultiplier {
+			return fmt.Errorf("%s has a payout out of range", sym.Emoji)
+		}
+	}
+	return nil
+}
+
+// spinSlotReel picks a symbol index with probability proportional to its weight.
+func spinSlotReel(symbols []database.SlotSymbol, sh Shuffler) int {
+	total := 0
+	for _, sym := range symbols {
+		total += sym.Weight
+	}
+	roll := sh.Intn(total)
+	for idx, sym := range symbols {
+		if roll < sym.Weight {
+			return idx
+		}
+		roll -= sym.Weight
+	}
+	return len(symbols) - 1
+}
+
+// slotsMultiplier returns the bet multiplier for a spin: three of a kind, then a pair on the first two reels.
+func slotsMultiplier(symbols []database.SlotSymbol, reels []int) int {
+	if reels[0] == reels[1] && reels[1] == reels[2] {
+		return symbols[reels[0]].Three
+	}
+	if reels[0] == reels[1] {
+		return symbols[reels[0]].Two
+	}
+	return 0
+}
+
+// slotsReturn is what a spin pays back, stake included. The commission only comes out of the part above the stake.
+func slotsReturn(bet, multiplier, commission int) int {
+	if multiplier <= 1 {
+		return bet * multiplier
+	}
+	return bet + afterCommission(bet*(multiplier-1), commission)
+}
+
+// slotsReturnRate is the share of every bet the machine pays back on average.
+func slotsReturnRate(symbols []database.SlotSymbol, commission int) float64 {
+	total := 0
+	for _, sym := range symbols {
+		total += sym.Weight
+	}
+	if total == 0 {
+		return 0
+	}
+
+	payback := func(multiplier int) float64 {
+		if multiplier <= 1 {
+			return float64(multiplier)
+		}
+		return 1 + float64(multiplier-1)*float64(100-commission)/100
+	}
+
+	rate := 0.0
+	for _, sym := range symbols {
+		p := float64(sym.Weight) / float64(total)
+		rate += p * p * p * payback(sym.Three)
+		rate += p * p * (1 - p) * payback(sym.Two)
+	}
+	return rate
+}
+
+func HandleSlotsCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	bet := 0
+	if len(data.Options) > 0 {
+		bet = int(data.Options[0].IntValue())
+	}
+	spinSlots(s, i, bet)
+}
+
+func createSlotsSpinAgainButtons(bet int) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: fmt.Sprintf("Spin Again ($%d)", bet), Style: discordgo.SuccessButton, CustomID: fmt.Sprintf("slots_spin:%d", bet)},
+			},
+		},
+	}
+}
+
+func HandleSlotsComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var bet int
+	if _, err := fmt.Sscanf(i.MessageComponentData().CustomID, "slots_spin:%d", &bet); err != nil {
+		return
+	}
+	spinSlots(s, i, bet)
+}
+
+// spinSlots takes the bet, spins the reels with the guild's paytable and pays out.
+func spinSlots(s *discordgo.Session, i *discordgo.InteractionCreate, bet int) {
+	userID := i.Member.User.ID
+	if bet <= 0 {
+		respondError(s, i, "Bet must be positive.")
+		return
+	}
+
+	bal, err := DB.GetBalance(userID)
+	if err != nil {
+		respondError(s, i, "Database error.")
+		return
+	}
+	if bal < bet {
+		respondError(s, i, "Insufficient funds.")
+		return
+	}
+	if err := DB.AddBalance(userID, -bet); err != nil {
+		respondError(s, i, "Transaction failed.")
+		return
+	}
+
+	settings := guildCasinoSettings(i.GuildID)
+	symbols := settings.SlotsSymbols
+	reels := make([]int, slotReels)
+	shown := make([]string, slotReels)
+	for idx := range reels {
+		reels[idx] = spinSlotReel(symbols, DefaultShuffler)
+		shown[idx] = symbols[reels[idx]].Emoji
+	}
+
+	multiplier := slotsMultiplier(symbols, reels)
+	payout := slotsReturn(bet, multiplier, settings.SlotsCommission)
+	if payout > 0 {
+		if err := DB.AddBalance(userID, payout); err != nil {
+			log.Printf("[SLOTS] Failed to pay %s $%d: %v", userID, payout, err)
+		}
+	}
+	log.Printf("[SLOTS] %s bet $%d | Reels: %s | Paid: $%d", i.Member.User.Username, bet, strings.Join(shown, ""), payout)
+
+	result := fmt.Sprintf("Lost $%d ❌", bet)
+	color := 0xe74c3c
+	switch {
+	case payout > bet:
+		result = fmt.Sprintf("**x%d!** Won $%d 🎉", multiplier, payout-bet)
+		color = 0x00FF00
+	case payout == bet:
+		result = "Got your bet back 🤝"
+		color = 0xf1c40f
+	case payout > 0:
+		result = fmt.Sprintf("Got $%d back", payout)
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:       "Slots",
+				Description: fmt.Sprintf("**[ %s ]**\n\n%s", strings.Join(shown, " | "), result),
+				Color:       color,
+				Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("%s bet $%d", i.Member.User.Username, bet)},
+			}},
+			Components: createSlotsSpinAgainButtons(bet),
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"math"
+	"testing"
+
+	"soosa/internal/database"
+)
+
+func TestSlotsMultiplier(t *testing.T) {
+	symbols := defaultSlotSymbols()
+	tests := []struct {
+		reels []int
+		want  int
+	}{
+		{[]int{6, 6, 6}, 500},
+		{[]int{0, 0, 0}, 10},
+		{[]int{5, 5, 1}, 5},
+		{[]int{1, 5, 5}, 0},
+		{[]int{0, 1, 2}, 0},
+	}
+	for _, tt := range tests {
+		if got := slotsMultiplier(symbols, tt.reels); got != tt.want {
+			t.Errorf("slotsMultiplier(%v) = %d, want %d", tt.reels, got, tt.want)
+		}
+	}
+}
+
+func TestSlotsReturn(t *testing.T) {
+	tests := []struct {
+		bet, multiplier, commission, want int
+	}{
+		{10, 0, 0, 0},
+		{10, 1, 50, 10},
+		{10, 5, 0, 50},
+		{10, 5, 10, 46},
+	}
+	for _, tt := range tests {
+		if got := slotsReturn(tt.bet, tt.multiplier, tt.commission); got != tt.want {
+			t.Errorf("slotsReturn(%d, x%d, %d%%) = %d, want %d", tt.bet, tt.multiplier, tt.commission, got, tt.want)
+		}
+	}
+}
+
+func TestSlotsReturnRateMatchesSpins(t *testing.T) {
+	symbols := defaultSlotSymbols()
+	rate := slotsReturnRate(symbols, 0)
+	if rate >= 1 || rate < 0.85 {
+		t.Fatalf("default paytable pays back %.3f", rate)
+	}
+	if err := validateSlotSymbols(symbols); err != nil {
+		t.Fatalf("default paytable is invalid: %v", err)
+	}
+
+	sh := NewSeededShuffler(11)
+	const spins = 400000
+	paid := 0
+	reels := make([]int, slotReels)
+	for n := 0; n < spins; n++ {
+		for idx := range reels {
+			reels[idx] = spinSlotReel(symbols, sh)
+		}
+		paid += slotsMultiplier(symbols, reels)
+	}
+	if got := float64(paid) / spins; math.Abs(got-rate) > 0.03 {
+		t.Errorf("simulated payback %.3f, calculated %.3f", got, rate)
+	}
+}
+
+func TestValidateSlotSymbols(t *testing.T) {
+	tests := []struct {
+		name    string
+		symbols []database.SlotSymbol
+		wantErr bool
+	}{
+		{"ok", []database.SlotSymbol{{Emoji: "🍒", Weight: 1, Three: 5}, {Emoji: "🍋", Weight: 2, Three: 3}}, false},
+		{"too few", []database.SlotSymbol{{Emoji: "🍒", Weight: 1, Three: 5}}, true},
+		{"duplicate", []database.SlotSymbol{{Emoji: "🍒", Weight: 1}, {Emoji: "🍒", Weight: 2}}, true},
+		{"zero weight", []database.SlotSymbol{{Emoji: "🍒", Weight: 0}, {Emoji: "🍋", Weight: 2}}, true},
+		{"negative payout", []database.SlotSymbol{{Emoji: "🍒", Weight: 1, Two: -1}, {Emoji: "🍋", Weight: 2}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSlotSymbols(tt.symbols); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
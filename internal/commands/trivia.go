@@ -0,0 +1,699 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"soosa/internal/database"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var TriviaCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "trivia",
+		Description: "Multiple-choice trivia",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "start",
+				Description: "Start a trivia match in this channel; anyone can answer",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "rounds",
+						Description: fmt.Sprintf("Number of questions (default %d, max %d)", triviaDefaultRounds, triviaMaxRounds),
+						Required:    false,
+						MinValue:    &[]float64{1}[0],
+						MaxValue:    triviaMaxRounds,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "category",
+						Description: "Only ask questions from this category (see /trivia categories)",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "difficulty",
+						Description: "Only ask questions of this difficulty",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Easy", Value: "easy"},
+							{Name: "Medium", Value: "medium"},
+							{Name: "Hard", Value: "hard"},
+						},
+					},
+				},
+			},
+			{
+				Name:        "stop",
+				Description: "End the match in this channel early (host or trivia admins)",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "categories",
+				Description: "List the question categories",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "import",
+				Description: "Add a question pack to this server (Open Trivia DB JSON format)",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionAttachment,
+						Name:        "file",
+						Description: "JSON file of questions",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+}
+
+const (
+	triviaDefaultRounds = 5
+	triviaMaxRounds     = 20
+	triviaQuestionTime  = 20 * time.Second
+	triviaRevealTime    = 5 * time.Second
+	triviaStartDelay    = 5 * time.Second
+	// maxTriviaImport caps how much of an uploaded question pack is read.
+	maxTriviaImport = 1024 * 1024
+)
+
+// triviaRewards is what a correct answer pays, by difficulty.
+var triviaRewards = map[string]int{
+	"easy":   25,
+	"medium": 50,
+	"hard":   100,
+}
+
+// triviaButtonLabels prefix the answers; Discord caps button labels at 80 characters.
+var triviaButtonLabels = []string{"A", "B", "C", "D"}
+
+type TriviaMatch struct {
+	ChannelID string
+	MessageID string
+	HostID    string
+	Questions []database.TriviaQuestion
+	Round     int
+	// Choices are the current question's answers in button order
+	Choices      []string
+	CorrectIndex int
+	Accepting    bool
+	Answers      map[string]int // userID -> choice index for the current question
+	Deadline     time.Time
+	Winnings     map[string]int // userID -> money earned this match
+	CorrectCount map[string]int
+	stop         chan struct{}
+	Stopped      bool
+}
+
+var (
+	// triviaMatches holds the running match for each channel.
+	triviaMatches = make(map[string]*TriviaMatch)
+	triviaMutex   sync.Mutex
+
+	// triviaBank is the bundled question bank, loaded at startup.
+	triviaBank []database.TriviaQuestion
+)
+
+// LoadTriviaQuestions loads the bundled question bank from a JSON file.
+func LoadTriviaQuestions(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load trivia questions from %s: %w", path, err)
+	}
+	questions, skipped, err := parseTriviaQuestions(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if skipped > 0 {
+		log.Printf("[TRIVIA] Skipped %d malformed questions in %s", skipped, path)
+	}
+	triviaBank = questions
+	return nil
+}
+
+// parseTriviaQuestions reads a bare JSON array of questions or an Open Trivia DB response ({"results": [...]}). HTML entities are decoded, and questions without a correct answer and one to three wrong ones are skipped.
+func parseTriviaQuestions(data []byte) ([]database.TriviaQuestion, int, error) {
+	var raw []database.TriviaQuestion
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var wrapped struct {
+			Results []database.TriviaQuestion `json:"results"`
+		}
+		if err2 := json.Unmarshal(data, &wrapped); err2 != nil {
+			return nil, 0, err
+		}
+		raw = wrapped.Results
+	}
+
+	var questions []database.TriviaQuestion
+	skipped := 0
+	for _, q := range raw {
+		q.Question = strings.TrimSpace(html.UnescapeString(q.Question))
+		q.Correct = strings.TrimSpace(html.UnescapeString(q.Correct))
+		q.Category = strings.TrimSpace(html.UnescapeString(q.Category))
+		q.Difficulty = strings.ToLower(strings.TrimSpace(q.Difficulty))
+		for idx := range q.Incorrect {
+			q.Incorrect[idx] = strings.TrimSpace(html.UnescapeString(q.Incorrect[idx]))
+		}
+
+		if q.Question == "" || q.Correct == "" || len(q.Incorrect) == 0 || len(q.Incorrect) >= len(triviaButtonLabels) {
+			skipped++
+			continue
+		}
+		if q.Category == "" {
+			q.Category = "General"
+		}
+		if _, ok := triviaRewards[q.Difficulty]; !ok {
+			q.Difficulty = "medium"
+		}
+		questions = append(questions, q)
+	}
+	return questions, skipped, nil
+}
+
+// triviaQuestionPool returns the bundled bank plus anything the guild imported.
+func triviaQuestionPool(guildID string) []database.TriviaQuestion {
+	pool := append([]database.TriviaQuestion{}, triviaBank...)
+	imported, err := DB.GetTriviaQuestions(guildID)
+	if err != nil {
+		log.Printf("[TRIVIA] Failed to load imported questions for %s: %v", guildID, err)
+	}
+	return append(pool, imported...)
+}
+
+// filterTriviaQuestions keeps the questions matching the category (case-insensitive) and difficulty; empty filters match everything.
+func filterTriviaQuestions(pool []database.TriviaQuestion, category, difficulty string) []database.TriviaQuestion {
+	var out []database.TriviaQuestion
+	for _, q := range pool {
+		if category != "" && !strings.EqualFold(q.Category, category) {
+			continue
+		}
+		if difficulty != "" && q.Difficulty != difficulty {
+			continue
+		}
+		out = append(out, q)
+	}
+	return out
+}
+
+// pickTriviaQuestions returns up to n distinct questions in random order.
+func pickTriviaQuestions(pool []database.TriviaQuestion, n int, sh Shuffler) []database.TriviaQuestion {
+	picked := append([]database.TriviaQuestion{}, pool...)
+	for i := len(picked) - 1; i > 0; i-- {
+		j := sh.Intn(i + 1)
+		picked[i], picked[j] = picked[j], picked[i]
+	}
+	if len(picked) > n {
+		picked = picked[:n]
+	}
+	return picked
+}
+
+// triviaChoices shuffles the answers for a question and returns them with the index of the correct one.
+func triviaChoices(q database.TriviaQuestion, sh Shuffler) ([]string, int) {
+	choices := append([]string{q.Correct}, q.Incorrect...)
+	for i := len(choices) - 1; i > 0; i-- {
+		j := sh.Intn(i + 1)
+		choices[i], choices[j] = choices[j], choices[i]
+	}
+	for idx, c := range choices {
+		if c == q.Correct {
+			return choices, idx
+		}
+	}
+	return choices, 0
+}
+
+func HandleTriviaCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	if len(data.Options) == 0 {
+		return
+	}
+
+	switch data.Options[0].Name {
+	case "start":
+		handleTriviaStart(s, i, data.Options[0].Options)
+	case "stop":
+		handleTriviaStop(s, i)
+	case "categories":
+		handleTriviaCategories(s, i)
+	case "import":
+		handleTriviaImport(s, i, data.Options[0].Options)
+	}
+}
+
+func handleTriviaStart(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	rounds := triviaDefaultRounds
+	var category, difficulty string
+	for _, opt := range options {
+		switch opt.Name {
+		case "rounds":
+			rounds = int(opt.IntValue())
+		case "category":
+			category = strings.TrimSpace(opt.StringValue())
+		case "difficulty":
+			difficulty = opt.StringValue()
+		}
+	}
+	if rounds < 1 || rounds > triviaMaxRounds {
+		respondError(s, i, fmt.Sprintf("Rounds must be between 1 and %d.", triviaMaxRounds))
+		return
+	}
+
+	triviaMutex.Lock()
+	_, running := triviaMatches[i.ChannelID]
+	triviaMutex.Unlock()
+	if running {
+		respondError(s, i, "A trivia match is already running in this channel.")
+		return
+	}
+
+	pool := filterTriviaQuestions(triviaQuestionPool(i.GuildID), category, difficulty)
+	if len(pool) == 0 {
+		respondError(s, i, "No questions match that category and difficulty. See `/trivia categories`.")
+		return
+	}
+
+	match := &TriviaMatch{
+		ChannelID:    i.ChannelID,
+		HostID:       i.Member.User.ID,
+		Questions:    pickTriviaQuestions(pool, rounds, DefaultShuffler),
+		Winnings:     make(map[string]int),
+		CorrectCount: make(map[string]int),
+		stop:         make(chan struct{}),
+	}
+
+	triviaMutex.Lock()
+	if _, running := triviaMatches[i.ChannelID]; running {
+		triviaMutex.Unlock()
+		respondError(s, i, "A trivia match is already running in this channel.")
+		return
+	}
+	triviaMatches[i.ChannelID] = match
+	triviaMutex.Unlock()
+
+	desc := fmt.Sprintf("<@%s> started a %d-question match. Anyone can answer; correct answers pay $%d / $%d / $%d for easy / medium / hard.\n\nFirst question <t:%d:R>.",
+		match.HostID, len(match.Questions), triviaRewards["easy"], triviaRewards["medium"], triviaRewards["hard"], time.Now().Add(triviaStartDelay).Unix())
+	if category != "" || difficulty != "" {
+		desc += "\n\n"
+		if category != "" {
+			desc += fmt.Sprintf("Category: **%s** ", match.Questions[0].Category)
+		}
+		if difficulty != "" {
+			desc += fmt.Sprintf("Difficulty: **%s**", difficulty)
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{Title: "Trivia", Description: desc, Color: 0x9b59b6}},
+		},
+	})
+	if err != nil {
+		removeTriviaMatch(match)
+		return
+	}
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		removeTriviaMatch(match)
+		return
+	}
+
+	triviaMutex.Lock()
+	match.MessageID = msg.ID
+	triviaMutex.Unlock()
+
+	log.Printf("[TRIVIA START] Host: %s | Channel: %s | Questions: %d | Category: '%s' | Difficulty: '%s'", match.HostID, match.ChannelID, len(match.Questions), category, difficulty)
+	go runTriviaMatch(s, match)
+}
+
+// waitTrivia sleeps for d, returning false early if the match is stopped.
+func waitTrivia(m *TriviaMatch, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-m.stop:
+		return false
+	}
+}
+
+func runTriviaMatch(s *discordgo.Session, m *TriviaMatch) {
+	if !waitTrivia(m, triviaStartDelay) {
+		finishTriviaMatch(s, m)
+		return
+	}
+
+	for round := range m.Questions {
+		triviaMutex.Lock()
+		m.Round = round
+		m.Choices, m.CorrectIndex = triviaChoices(m.Questions[round], DefaultShuffler)
+		m.Answers = make(map[string]int)
+		m.Deadline = time.Now().Add(triviaQuestionTime)
+		m.Accepting = true
+		embed := buildTriviaQuestionEmbed(m)
+		components := createTriviaAnswerButtons(m, false)
+		triviaMutex.Unlock()
+
+		editTriviaMessage(s, m, embed, components)
+		stopped := !waitTrivia(m, triviaQuestionTime)
+
+		triviaMutex.Lock()
+		m.Accepting = false
+		winners := scoreTriviaRound(m)
+		embed = buildTriviaRevealEmbed(m, winners)
+		components = createTriviaAnswerButtons(m, true)
+		triviaMutex.Unlock()
+
+		editTriviaMessage(s, m, embed, components)
+		if stopped || round == len(m.Questions)-1 {
+			break
+		}
+		if !waitTrivia(m, triviaRevealTime) {
+			break
+		}
+	}
+
+	// Leave the last answer up for a moment before the final scores
+	waitTrivia(m, triviaRevealTime)
+	finishTriviaMatch(s, m)
+}
+
+// scoreTriviaRound credits everyone who picked the right answer and returns them. Call with triviaMutex held.
+func scoreTriviaRound(m *TriviaMatch) []string {
+	reward := triviaRewards[m.Questions[m.Round].Difficulty]
+	var winners []string
+	for userID, choice := range m.Answers {
+		if choice != m.CorrectIndex {
+			continue
+		}
+		m.Winnings[userID] += reward
+		m.CorrectCount[userID]++
+		winners = append(winners, userID)
+	}
+	sort.Strings(winners)
+	return winners
+}
+
+func finishTriviaMatch(s *discordgo.Session, m *TriviaMatch) {
+	removeTriviaMatch(m)
+
+	triviaMutex.Lock()
+	payouts := make(map[string]int, len(m.Winnings))
+	for userID, amount := range m.Winnings {
+		payouts[userID] = amount
+	}
+	embed := buildTriviaFinalEmbed(m)
+	triviaMutex.Unlock()
+
+	for userID, amount := range payouts {
+		if err := DB.AddBalance(userID, amount); err != nil {
+			log.Printf("[TRIVIA] Failed to pay %s $%d: %v", userID, amount, err)
+		}
+	}
+	log.Printf("[TRIVIA FINISH] Channel: %s | Players paid: %d", m.ChannelID, len(payouts))
+
+	editTriviaMessage(s, m, embed, []discordgo.MessageComponent{})
+}
+
+func removeTriviaMatch(m *TriviaMatch) {
+	triviaMutex.Lock()
+	defer triviaMutex.Unlock()
+	if triviaMatches[m.ChannelID] == m {
+		delete(triviaMatches, m.ChannelID)
+	}
+}
+
+func editTriviaMessage(s *discordgo.Session, m *TriviaMatch, embed *discordgo.MessageEmbed, components []discordgo.MessageComponent) {
+	if _, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		Channel:    m.ChannelID,
+		ID:         m.MessageID,
+		Embed:      embed,
+		Components: &components,
+	}); err != nil {
+		log.Printf("[TRIVIA] Failed to update match message: %v", err)
+	}
+}
+
+func triviaQuestionHeader(m *TriviaMatch) string {
+	q := m.Questions[m.Round]
+	return fmt.Sprintf("Question %d/%d | %s | %s ($%d)", m.Round+1, len(m.Questions), q.Category, strings.ToUpper(q.Difficulty[:1])+q.Difficulty[1:], triviaRewards[q.Difficulty])
+}
+
+func buildTriviaQuestionEmbed(m *TriviaMatch) *discordgo.MessageEmbed {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s**\n\n", m.Questions[m.Round].Question))
+	for idx, choice := range m.Choices {
+		sb.WriteString(fmt.Sprintf("**%s.** %s\n", triviaButtonLabels[idx], choice))
+	}
+	sb.WriteString(fmt.Sprintf("\nTime's up <t:%d:R>", m.Deadline.Unix()))
+
+	return &discordgo.MessageEmbed{
+		Title:       "Trivia",
+		Description: sb.String(),
+		Color:       0x9b59b6,
+		Footer:      &discordgo.MessageEmbedFooter{Text: triviaQuestionHeader(m)},
+	}
+}
+
+func buildTriviaRevealEmbed(m *TriviaMatch, winners []string) *discordgo.MessageEmbed {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**%s**\n\n", m.Questions[m.Round].Question))
+	for idx, choice := range m.Choices {
+		mark := "❌"
+		if idx == m.CorrectIndex {
+			mark = "✅"
+		}
+		sb.WriteString(fmt.Sprintf("%s **%s.** %s\n", mark, triviaButtonLabels[idx], choice))
+	}
+
+	sb.WriteString("\n")
+	switch {
+	case len(m.Answers) == 0:
+		sb.WriteString("Nobody answered.")
+	case len(winners) == 0:
+		sb.WriteString(fmt.Sprintf("Nobody got it out of %d.", len(m.Answers)))
+	default:
+		mentions := make([]string, len(winners))
+		for idx, userID := range winners {
+			mentions[idx] = fmt.Sprintf("<@%s>", userID)
+		}
+		sb.WriteString(fmt.Sprintf("Correct (%d/%d): %s", len(winners), len(m.Answers), strings.Join(mentions, ", ")))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Trivia",
+		Description: sb.String(),
+		Color:       0x2ecc71,
+		Footer:      &discordgo.MessageEmbedFooter{Text: triviaQuestionHeader(m)},
+	}
+}
+
+func buildTriviaFinalEmbed(m *TriviaMatch) *discordgo.MessageEmbed {
+	type standing struct {
+		UserID  string
+		Correct int
+		Won     int
+	}
+	var standings []standing
+	for userID, won := range m.Winnings {
+		standings = append(standings, standing{userID, m.CorrectCount[userID], won})
+	}
+	sort.Slice(standings, func(a, b int) bool {
+		if standings[a].Won != standings[b].Won {
+			return standings[a].Won > standings[b].Won
+		}
+		return standings[a].UserID < standings[b].UserID
+	})
+
+	// Choices is only set once the first question is shown
+	asked := 0
+	if m.Choices != nil {
+		asked = m.Round + 1
+	}
+
+	var sb strings.Builder
+	if m.Stopped {
+		sb.WriteString("The match was stopped early.\n\n")
+	}
+	if len(standings) == 0 {
+		sb.WriteString("Nobody scored this time.")
+	}
+	for rank, st := range standings {
+		sb.WriteString(fmt.Sprintf("**%d.** <@%s> - %d correct, **$%d**\n", rank+1, st.UserID, st.Correct, st.Won))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       "Trivia - Final Scores",
+		Description: sb.String(),
+		Color:       0xf1c40f,
+		Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("%d questions asked", asked)},
+	}
+}
+
+func createTriviaAnswerButtons(m *TriviaMatch, disabled bool) []discordgo.MessageComponent {
+	var buttons []discordgo.MessageComponent
+	for idx := range m.Choices {
+		style := discordgo.PrimaryButton
+		if disabled {
+			style = discordgo.SecondaryButton
+			if idx == m.CorrectIndex {
+				style = discordgo.SuccessButton
+			}
+		}
+		buttons = append(buttons, discordgo.Button{
+			Label:    triviaButtonLabels[idx],
+			Style:    style,
+			CustomID: fmt.Sprintf("trivia_answer:%d", idx),
+			Disabled: disabled,
+		})
+	}
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+func HandleTriviaComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	id := i.MessageComponentData().CustomID
+	userID := i.Member.User.ID
+
+	var choice int
+	if _, err := fmt.Sscanf(id, "trivia_answer:%d", &choice); err != nil {
+		return
+	}
+
+	triviaMutex.Lock()
+	m, exists := triviaMatches[i.ChannelID]
+	var msg string
+	switch {
+	case !exists || m.MessageID != i.Message.ID:
+		msg = "This match is over."
+	case !m.Accepting || time.Now().After(m.Deadline):
+		msg = "Too late, answers are closed."
+	case choice < 0 || choice >= len(m.Choices):
+		msg = "That isn't an answer."
+	default:
+		if prev, answered := m.Answers[userID]; answered {
+			msg = fmt.Sprintf("You already locked in **%s**.", triviaButtonLabels[prev])
+		} else {
+			m.Answers[userID] = choice
+			msg = fmt.Sprintf("Locked in **%s. %s**", triviaButtonLabels[choice], m.Choices[choice])
+		}
+	}
+	triviaMutex.Unlock()
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Content: msg, Flags: discordgo.MessageFlagsEphemeral},
+	})
+}
+
+func handleTriviaStop(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	triviaMutex.Lock()
+	m, exists := triviaMatches[i.ChannelID]
+	if !exists {
+		triviaMutex.Unlock()
+		respondError(s, i, "There's no trivia match in this channel.")
+		return
+	}
+	if m.HostID != i.Member.User.ID && !hasPermission(i.Member.User.ID, "admin.trivia") {
+		triviaMutex.Unlock()
+		respondError(s, i, "Only the host can stop this match.")
+		return
+	}
+	if !m.Stopped {
+		m.Stopped = true
+		close(m.stop)
+	}
+	triviaMutex.Unlock()
+
+	log.Printf("[TRIVIA STOP] Channel: %s | By: %s", m.ChannelID, i.Member.User.ID)
+	respondSuccess(s, i, "Trivia match stopped. Winnings so far will be paid out.")
+}
+
+func handleTriviaCategories(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	counts := make(map[string]int)
+	for _, q := range triviaQuestionPool(i.GuildID) {
+		counts[q.Category]++
+	}
+	if len(counts) == 0 {
+		respondError(s, i, "No trivia questions are loaded.")
+		return
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("**%s** - %d questions\n", name, counts[name]))
+	}
+	respondEmbed(s, i, &discordgo.MessageEmbed{
+		Title:       "Trivia Categories",
+		Description: sb.String(),
+		Color:       0x9b59b6,
+	})
+}
+
+func handleTriviaImport(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !hasPermission(i.Member.User.ID, "admin.trivia") {
+		respondError(s, i, "You do not have permission to use this command.")
+		return
+	}
+	if len(options) == 0 {
+		return
+	}
+
+	attachmentID, _ := options[0].Value.(string)
+	attachment, ok := i.ApplicationCommandData().Resolved.Attachments[attachmentID]
+	if !ok {
+		respondError(s, i, "Couldn't find the uploaded file.")
+		return
+	}
+
+	data, err := downloadAttachment(attachment.URL, maxTriviaImport)
+	if err != nil {
+		log.Printf("[TRIVIA] Failed to download question pack: %v", err)
+		respondError(s, i, "Couldn't read the uploaded file.")
+		return
+	}
+
+	questions, skipped, err := parseTriviaQuestions(data)
+	if err != nil {
+		respondError(s, i, fmt.Sprintf("That isn't a valid question pack: %v", err))
+		return
+	}
+	if len(questions) == 0 {
+		respondError(s, i, "The file has no usable questions. Each needs a question, a correct_answer and one to three incorrect_answers.")
+		return
+	}
+
+	added, err := DB.AddTriviaQuestions(i.GuildID, questions)
+	if err != nil {
+		log.Printf("[TRIVIA] Failed to import questions: %v", err)
+		respondError(s, i, "Database error.")
+		return
+	}
+	log.Printf("[TRIVIA IMPORT] Guild %s: %d added, %d duplicates, %d malformed by %s", i.GuildID, added, len(questions)-added, skipped, i.Member.User.ID)
+
+	msg := fmt.Sprintf("Imported %d new questions.", added)
+	if dupes := len(questions) - added; dupes > 0 {
+		msg += fmt.Sprintf(" %d were already here.", dupes)
+	}
+	if skipped > 0 {
+		msg += fmt.Sprintf(" Skipped %d malformed questions.", skipped)
+	}
+	respondSuccess(s, i, msg)
+}
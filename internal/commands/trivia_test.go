@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"soosa/internal/database"
+)
+
+func TestParseTriviaQuestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		skipped int
+		wantErr bool
+	}{
+		{
+			name: "bare array",
+			data: `[{"category":"Science","difficulty":"easy","question":"Q1","correct_answer":"A","incorrect_answers":["B","C","D"]}]`,
+			want: 1,
+		},
+		{
+			name: "open trivia db response",
+			data: `{"response_code":0,"results":[{"category":"History","difficulty":"hard","question":"Q1","correct_answer":"True","incorrect_answers":["False"]}]}`,
+			want: 1,
+		},
+		{
+			name:    "malformed questions are skipped",
+			data:    `[{"question":"","correct_answer":"A","incorrect_answers":["B"]},{"question":"Q","correct_answer":"A","incorrect_answers":[]},{"question":"Q","correct_answer":"A","incorrect_answers":["B","C","D","E"]},{"question":"Q","correct_answer":"A","incorrect_answers":["B"]}]`,
+			want:    1,
+			skipped: 3,
+		},
+		{
+			name:    "not json",
+			data:    `question,answer`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skipped, err := parseTriviaQuestions([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want || skipped != tt.skipped {
+				t.Errorf("got %d questions and %d skipped, want %d and %d", len(got), skipped, tt.want, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestParseTriviaQuestionsNormalizes(t *testing.T) {
+	got, _, err := parseTriviaQuestions([]byte(`[{"difficulty":"EXTREME","question":"Who wrote &quot;Hamlet&quot;?","correct_answer":"Shakespeare","incorrect_answers":["Marlowe &amp; Kyd"]}]`))
+	if err != nil || len(got) != 1 {
+		t.Fatalf("parse failed: %v", err)
+	}
+	q := got[0]
+	if q.Question != `Who wrote "Hamlet"?` || q.Incorrect[0] != "Marlowe & Kyd" {
+		t.Errorf("entities not decoded: %q / %q", q.Question, q.Incorrect[0])
+	}
+	if q.Category != "General" || q.Difficulty != "medium" {
+		t.Errorf("got category %q difficulty %q, want General and medium", q.Category, q.Difficulty)
+	}
+}
+
+func TestBundledTriviaQuestions(t *testing.T) {
+	data, err := os.ReadFile("../../trivia_questions.json")
+	if err != nil {
+		t.Skipf("bundled bank not found: %v", err)
+	}
+	questions, skipped, err := parseTriviaQuestions(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped > 0 || len(questions) < triviaMaxRounds {
+		t.Errorf("bundled bank has %d usable questions and %d malformed", len(questions), skipped)
+	}
+}
+
+func TestFilterTriviaQuestions(t *testing.T) {
+	pool := []database.TriviaQuestion{
+		{Category: "Science", Difficulty: "easy"},
+		{Category: "Science", Difficulty: "hard"},
+		{Category: "History", Difficulty: "easy"},
+	}
+	tests := []struct {
+		category, difficulty string
+		want                 int
+	}{
+		{"", "", 3},
+		{"science", "", 2},
+		{"", "easy", 2},
+		{"Science", "hard", 1},
+		{"Music", "", 0},
+	}
+	for _, tt := range tests {
+		if got := filterTriviaQuestions(pool, tt.category, tt.difficulty); len(got) != tt.want {
+			t.Errorf("filter(%q, %q) = %d questions, want %d", tt.category, tt.difficulty, len(got), tt.want)
+		}
+	}
+}
+
+func TestPickTriviaQuestions(t *testing.T) {
+	pool := []database.TriviaQuestion{{Question: "1"}, {Question: "2"}, {Question: "3"}, {Question: "4"}}
+	got := pickTriviaQuestions(pool, 3, NewSeededShuffler(1))
+	if len(got) != 3 {
+		t.Fatalf("picked %d questions, want 3", len(got))
+	}
+	seen := make(map[string]bool)
+	for _, q := range got {
+		if seen[q.Question] {
+			t.Errorf("question %s picked twice", q.Question)
+		}
+		seen[q.Question] = true
+	}
+	if pool[0].Question != "1" || pool[3].Question != "4" {
+		t.Error("picking reordered the pool")
+	}
+	if got := pickTriviaQuestions(pool, 10, NewSeededShuffler(1)); len(got) != len(pool) {
+		t.Errorf("picked %d questions from a pool of %d", len(got), len(pool))
+	}
+}
+
+func TestTriviaChoices(t *testing.T) {
+	q := database.TriviaQuestion{Correct: "Right", Incorrect: []string{"W1", "W2", "W3"}}
+	positions := make(map[int]int)
+	sh := NewSeededShuffler(3)
+	for n := 0; n < 400; n++ {
+		choices, correct := triviaChoices(q, sh)
+		if len(choices) != 4 || choices[correct] != "Right" {
+			t.Fatalf("choices %v with correct index %d", choices, correct)
+		}
+		positions[correct]++
+	}
+	// The right answer shouldn't always land on the same button
+	if len(positions) != 4 {
+		t.Errorf("correct answer only appeared at positions %v", positions)
+	}
+	if len(q.Incorrect) != 3 || q.Incorrect[0] != "W1" {
+		t.Error("shuffling modified the question")
+	}
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var VerifyCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "verify",
+		Description: "Check a provably fair deal against its published hash",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "seed",
+				Description: "The seed revealed after the hand",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "hash",
+				Description: "The deck hash shown before the hand",
+				Required:    true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "client",
+				Description: "The client seed shown after the hand, if players added one",
+				Required:    false,
+			},
+		},
+	},
+}
+
+func HandleVerifyCommand(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	var seed, hash, client string
+	for _, opt := range data.Options {
+		switch opt.Name {
+		case "seed":
+			seed = opt.StringValue()
+		case "hash":
+			hash = opt.StringValue()
+		case "client":
+			client = opt.StringValue()
+		}
+	}
+
+	deck, err := VerifyFairDeal(seed, hash, client)
+	if err != nil {
+		log.Printf("[VERIFY] User %s: %v", i.Member.User.ID, err)
+		respondError(s, i, "Verification failed: "+err.Error()+".")
+		return
+	}
+
+	// Deal order as the games consume it, in rows of 13
+	var rows []string
+	for start := 0; start < len(deck); start += 13 {
+		rows = append(rows, formatHand(deck[start:start+13]))
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:       "✅ Deal Verified",
+				Description: fmt.Sprintf("The seed matches hash `%s`. This is the full deck in the order it was dealt:\n\n%s", strings.ToLower(strings.TrimSpace(hash)), strings.Join(rows, "\n")),
+				Color:       0x00FF00,
+			}},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
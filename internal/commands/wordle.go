@@ -8,7 +8,9 @@ import (
 	"log"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"soosa/internal/database"
@@ -16,9 +18,48 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
+// wordleWordList is the answer and guess list for one language and word length. Allowed is a set so validating a guess doesn't scan the whole dictionary.
+type wordleWordList struct {
+	Answers []string
+	Allowed map[string]struct{}
+}
+
+// newWordleWordList dedupes the answers and indexes them together with the allowed guesses.
+func newWordleWordList(answers, allowed []string) *wordleWordList {
+	list := &wordleWordList{Allowed: make(map[string]struct{}, len(allowed)+len(answers))}
+	for _, word := range allowed {
+		list.Allowed[word] = struct{}{}
+	}
+	seen := make(map[string]bool, len(answers))
+	for _, word := range answers {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		list.Answers = append(list.Answers, word)
+		// Every answer must also be a valid guess
+		list.Allowed[word] = struct{}{}
+	}
+	return list
+}
+
+// Contains reports whether word is an allowed guess.
+func (l *wordleWordList) Contains(word string) bool {
+	_, ok := l.Allowed[word]
+	return ok
+}
+
+// wordleListKey identifies a word list by language and word length.
+type wordleListKey struct {
+	Lang   string
+	Length int
+}
+
 var (
-	answers []string
-	allowed []string
+	// wordLists holds a list per supported language and word length. Five letters is the classic game and the only length with a daily word.
+	wordLists = make(map[wordleListKey]*wordleWordList)
+	// wordListsMutex guards wordLists and wordlePacks, which are swapped out wholesale when the files are reloaded.
+	wordListsMutex sync.RWMutex
 )
 
 const (
@@ -27,24 +68,90 @@ const (
 	StatusGreen  = 2
 )
 
-// LoadWordleWords loads the answer and allowed word lists from the specified files.
+const classicWordLength = 5
+
+// wordleLengths are the variants offered by /wordle guess length. Each needs wordlist_answers_N.txt and wordlist_allowed_N.txt next to the classic lists.
+var wordleLengths = []int{4, 5, 6, 7}
+
+// LoadWordleWords loads the classic answer and allowed word lists from the specified files, plus any other-length and other-language lists found beside them. The previous lists stay in place if the classic pair can't be read.
 func LoadWordleWords(answersPath, allowedPath string) error {
-	var err error
-	answers, err = readLines(answersPath)
+	list, err := loadWordleWordList(defaultWordleLang, answersPath, allowedPath)
 	if err != nil {
-		return fmt.Errorf("failed to load answers from %s: %w", answersPath, err)
+		return err
 	}
+	lists := map[wordleListKey]*wordleWordList{{defaultWordleLang, classicWordLength}: list}
 
-	allowed, err = readLines(allowedPath)
-	if err != nil {
-		return fmt.Errorf("failed to load allowed guesses from %s: %w", allowedPath, err)
+	for _, lang := range wordleLanguages {
+		for _, length := range wordleLengths {
+			key := wordleListKey{lang.Code, length}
+			if key == (wordleListKey{defaultWordleLang, classicWordLength}) {
+				continue
+			}
+			list, err := loadWordleWordList(lang.Code, wordListPath(answersPath, lang.Code, length), wordListPath(allowedPath, lang.Code, length))
+			if err != nil {
+				// Most languages only ship the classic length, so only log what's missing for those
+				if lang.Code == defaultWordleLang || length == classicWordLength {
+					log.Printf("[WORDLE] %d-letter %s disabled: %v", length, lang.Name, err)
+				}
+				continue
+			}
+			lists[key] = list
+		}
 	}
 
-	// Append answers to allowed list to ensure all answers are valid guesses if not already present
-	allowed = append(allowed, answers...)
+	wordListsMutex.Lock()
+	wordLists = lists
+	wordleAnswersPath, wordleAllowedPath = answersPath, allowedPath
+	wordListsMutex.Unlock()
 	return nil
 }
 
+func loadWordleWordList(lang, answersPath, allowedPath string) (*wordleWordList, error) {
+	answers, err := readLines(answersPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load answers from %s: %w", answersPath, err)
+	}
+
+	allowed, err := readLines(allowedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load allowed guesses from %s: %w", allowedPath, err)
+	}
+
+	for idx := range answers {
+		answers[idx] = normalizeWordleWord(lang, answers[idx])
+	}
+	for idx := range allowed {
+		allowed[idx] = normalizeWordleWord(lang, allowed[idx])
+	}
+	return newWordleWordList(answers, allowed), nil
+}
+
+// wordListPath turns wordlist_answers.txt into wordlist_answers_6.txt for other lengths and wordlist_answers_es.txt (or wordlist_answers_es_6.txt) for other languages.
+func wordListPath(path, lang string, length int) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	if lang != defaultWordleLang {
+		base += "_" + lang
+	}
+	if length != classicWordLength {
+		base += fmt.Sprintf("_%d", length)
+	}
+	return base + ext
+}
+
+// getWordleWordList returns the loaded list for the language and length, or nil.
+func getWordleWordList(lang string, length int) *wordleWordList {
+	wordListsMutex.RLock()
+	defer wordListsMutex.RUnlock()
+	return wordLists[wordleListKey{lang, length}]
+}
+
+// wordleLengthAvailable reports whether a word list is loaded for the language and length.
+func wordleLengthAvailable(lang string, length int) bool {
+	list := getWordleWordList(lang, length)
+	return list != nil && len(list.Answers) > 0
+}
+
 func readLines(path string) ([]string, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -68,16 +175,16 @@ func readLines(path string) ([]string, error) {
 func EvaluateGuess(guess, target string) []int {
 	guess = strings.ToUpper(guess)
 	target = strings.ToUpper(target)
-	result := make([]int, 5)
 
 	targetRunes := []rune(target)
 	guessRunes := []rune(guess)
+	result := make([]int, len(guessRunes))
 
 	targetFreq := make(map[rune]int)
 
 	// First pass: Check for Greens and build frequency map for non-green target letters
 	for i, char := range targetRunes {
-		if guessRunes[i] == char {
+		if i < len(guessRunes) && guessRunes[i] == char {
 			result[i] = StatusGreen
 		} else {
 			targetFreq[char]++
@@ -101,12 +208,68 @@ func EvaluateGuess(guess, target string) []int {
 	return result
 }
 
-// GetDailyWord returns the daily word based on the current date (UTC). Using a SHA256 hash of the date to seed a random generator ensures deterministic but random selection from the answers list.
+// CheckHardMode returns an error naming the first revealed hint the guess ignores: greens must stay in place and yellows must be reused.
+func CheckHardMode(guess string, previous []string, target string) error {
+	guessRunes := []rune(strings.ToUpper(guess))
+
+	for _, prev := range previous {
+		prevRunes := []rune(strings.ToUpper(prev))
+		eval := EvaluateGuess(prev, target)
+
+		required := make(map[rune]int)
+		for idx, status := range eval {
+			if status == StatusGreen && (idx >= len(guessRunes) || guessRunes[idx] != prevRunes[idx]) {
+				return fmt.Errorf("%s letter must be %c", ordinal(idx+1), prevRunes[idx])
+			}
+			if status != StatusGray {
+				required[prevRunes[idx]]++
+			}
+		}
+
+		for idx, status := range eval {
+			if status != StatusYellow {
+				continue
+			}
+			letter := prevRunes[idx]
+			have := 0
+			for _, r := range guessRunes {
+				if r == letter {
+					have++
+				}
+			}
+			if have < required[letter] {
+				return fmt.Errorf("guess must contain %c", letter)
+			}
+		}
+	}
+	return nil
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
+
+// GetDailyWord returns the daily word for the language based on the current date (UTC). Using a SHA256 hash of the date to seed a random generator ensures deterministic but random selection from the answers list.
 
-func GetDailyWord(t time.Time) string {
-	if len(answers) == 0 {
+func GetDailyWord(lang string, t time.Time) string {
+	list := getWordleWordList(lang, classicWordLength)
+	if list == nil || len(list.Answers) == 0 {
 		return "ERROR"
 	}
+	return dailyWordFrom(list.Answers, t)
+}
+
+// dailyWordFrom picks the word for t's date from answers, so every player drawing from the same list gets the same word.
+func dailyWordFrom(answers []string, t time.Time) string {
 	dateStr := t.UTC().Format("2006-01-02")
 	hash := sha256.Sum256([]byte(dateStr))
 	seed := int64(binary.BigEndian.Uint64(hash[:8]))
@@ -116,26 +279,21 @@ func GetDailyWord(t time.Time) string {
 	return answers[index]
 }
 
-// GetRandomWord returns a random word from the answers list.
-func GetRandomWord() string {
-	if len(answers) == 0 {
+// GetRandomWord returns a random word of the given language and length from the answers list.
+func GetRandomWord(lang string, length int) string {
+	list := getWordleWordList(lang, length)
+	if list == nil || len(list.Answers) == 0 {
 		return "ERROR"
 	}
-	return answers[rand.Intn(len(answers))]
+	answers := list.Answers
+	return answers[DefaultShuffler.Intn(len(answers))]
 }
 
-// IsValidWord checks if the word is in the allowed list (or answers list).
-func IsValidWord(word string) bool {
-	if len(allowed) == 0 {
-		return false
-	}
-	word = strings.ToUpper(word)
-	for _, w := range allowed {
-		if w == word {
-			return true
-		}
-	}
-	return false
+// IsValidWord checks if the word is in the allowed list (or answers list) for its language and length.
+func IsValidWord(lang, word string) bool {
+	word = normalizeWordleWord(lang, word)
+	list := getWordleWordList(lang, len([]rune(word)))
+	return list != nil && list.Contains(word)
 }
 
 // CalculateWordleReward returns the reward amount based on the number of attempts (1-indexed).
@@ -169,6 +327,31 @@ var WordleCommands = []*discordgo.ApplicationCommand{
 						Description: "Make a guess immediately",
 						Required:    false,
 					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "length",
+						Description: "Word length for a new game (5 is the daily; others are random words)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "4 letters", Value: 4},
+							{Name: "5 letters", Value: 5},
+							{Name: "6 letters", Value: 6},
+							{Name: "7 letters", Value: 7},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "hard",
+						Description: "Hard mode for a new game: revealed hints must be used in later guesses",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "lang",
+						Description: "Language for a new game (default English)",
+						Required:    false,
+						Choices:     wordleLanguageChoices(),
+					},
 				},
 			},
 			{
@@ -176,6 +359,122 @@ var WordleCommands = []*discordgo.ApplicationCommand{
 				Description: "Forfeit the current game",
 				Type:        discordgo.ApplicationCommandOptionSubCommand,
 			},
+			{
+				Name:        "archive",
+				Description: "Play a past daily puzzle you missed (half reward, no stats)",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "date",
+						Description: "Puzzle date as YYYY-MM-DD",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "hard",
+						Description: "Hard mode: revealed hints must be used in later guesses",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "lang",
+						Description: "Puzzle language (default English)",
+						Required:    false,
+						Choices:     wordleLanguageChoices(),
+					},
+				},
+			},
+			{
+				Name:        "leaderboard",
+				Description: "Show this server's daily Wordle leaderboard",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "period",
+						Description: "Time range (default: today)",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Today", Value: "day"},
+							{Name: "This week", Value: "week"},
+							{Name: "All time", Value: "alltime"},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "lang",
+						Description: "Which language's daily (default English)",
+						Required:    false,
+						Choices:     wordleLanguageChoices(),
+					},
+				},
+			},
+			{
+				Name:        "race",
+				Description: "Challenge someone to solve the same word first",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Name:        "opponent",
+						Description: "Who to race",
+						Required:    true,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        "wager",
+						Description: "Amount each player puts in; winner takes all (default 0)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "admin",
+				Description: "Wordle server settings",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "wordlist",
+						Description: "Use a themed pack or your own list for the daily word (no options shows the current list)",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:        discordgo.ApplicationCommandOptionString,
+								Name:        "pack",
+								Description: "Name of a bundled themed pack",
+								Required:    false,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionAttachment,
+								Name:        "file",
+								Description: "Text file with one 5-letter answer per line",
+								Required:    false,
+							},
+							{
+								Type:        discordgo.ApplicationCommandOptionBoolean,
+								Name:        "reset",
+								Description: "Go back to the default answer list",
+								Required:    false,
+							},
+						},
+					},
+					{
+						Name:        "results-channel",
+						Description: "Post everyone's daily results here each night (omit to turn off)",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:         discordgo.ApplicationCommandOptionChannel,
+								Name:         "channel",
+								Description:  "Channel for the nightly results post",
+								Required:     false,
+								ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+							},
+						},
+					},
+				},
+			},
 		},
 	},
 }
@@ -189,16 +488,46 @@ func HandleWordleCommand(s *discordgo.Session, i *discordgo.InteractionCreate, d
 	switch data.Options[0].Name {
 	case "guess":
 		var word string
-		if len(data.Options[0].Options) > 0 {
-			word = data.Options[0].Options[0].StringValue()
+		length := classicWordLength
+		hard := false
+		lang := defaultWordleLang
+		for _, opt := range data.Options[0].Options {
+			switch opt.Name {
+			case "word":
+				word = opt.StringValue()
+			case "length":
+				length = int(opt.IntValue())
+			case "hard":
+				hard = opt.BoolValue()
+			case "lang":
+				lang = opt.StringValue()
+			}
 		}
-		handleGuessCommand(s, i, word)
+		handleGuessCommand(s, i, word, length, hard, lang)
 	case "giveup":
 		handleGiveUpCommand(s, i)
+	case "leaderboard":
+		period := "day"
+		lang := defaultWordleLang
+		for _, opt := range data.Options[0].Options {
+			switch opt.Name {
+			case "period":
+				period = opt.StringValue()
+			case "lang":
+				lang = opt.StringValue()
+			}
+		}
+		handleWordleLeaderboard(s, i, period, lang)
+	case "archive":
+		handleWordleArchiveCommand(s, i, data.Options[0].Options)
+	case "race":
+		handleWordleRaceCommand(s, i, data.Options[0].Options)
+	case "admin":
+		handleWordleAdminCommand(s, i, data.Options[0].Options)
 	}
 }
 
-func handleGuessCommand(s *discordgo.Session, i *discordgo.InteractionCreate, guessArg string) {
+func handleGuessCommand(s *discordgo.Session, i *discordgo.InteractionCreate, guessArg string, length int, hard bool, lang string) {
 	userID := i.Member.User.ID
 
 	// Check if user has an active game
@@ -222,27 +551,60 @@ func handleGuessCommand(s *discordgo.Session, i *discordgo.InteractionCreate, gu
 		return
 	}
 
-	// No active game, check if Daily played today
+	if !wordleLengthAvailable(lang, length) {
+		respondError(s, i, fmt.Sprintf("%d-letter Wordle isn't available in %s.", length, wordleLanguageName(lang)))
+		return
+	}
+
 	today := time.Now().UTC().Format("2006-01-02")
-	if state != nil && state.Completed {
-		if state.GameType == "DAILY" && state.LastPlayed == today {
+	var newState *database.WordleState
+
+	if length != classicWordLength {
+		// Only classic Wordle has a daily word; other lengths are always random
+		newState = newRandomWordleState(userID, length, hard, lang)
+	} else {
+		// No active game, check if Daily played today. The results table remembers it even after a random game replaced the saved state.
+		playedToday, _ := DB.HasWordleResult(userID, today, lang)
+		if playedToday || (state != nil && state.Completed && state.GameType == "DAILY" && state.LastPlayed == today && state.Lang == lang) {
 			// Daily finished today. Offer Random.
-			respondWithStatsAndRandomOffer(s, i.Interaction, userID)
+			respondWithStatsAndRandomOffer(s, i.Interaction, userID, hard, lang)
 			return
 		}
+
+		// Start new Daily Game
+		word := GetGuildDailyWord(i.GuildID, lang, time.Now().UTC())
+		newState = &database.WordleState{
+			UserID:     userID,
+			GameType:   "DAILY",
+			Word:       word,
+			Guesses:    []string{},
+			LastPlayed: today,
+			Completed:  false,
+			HardMode:   hard,
+			Lang:       lang,
+		}
 	}
+	log.Printf("[WORDLE START] User: %s | Mode: %s (%s) | Word: %s", userID, newState.GameType, wordleMode(newState), newState.Word)
 
-	// Start new Daily Game
-	word := GetDailyWord(time.Now().UTC())
-	newState := &database.WordleState{
-		UserID:     userID,
-		GameType:   "DAILY",
-		Word:       word,
-		Guesses:    []string{},
-		LastPlayed: today,
-		Completed:  false,
+	if err := startWordleGame(s, i.ChannelID, newState); err != nil {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: "Failed to start game.", Flags: discordgo.MessageFlagsEphemeral},
+		})
+		return
+	}
+
+	// Check if a guess was provided
+	if guessArg != "" {
+		handleGuess(s, i, newState, guessArg)
+	} else {
+		respondWithGame(s, i.Interaction, newState)
 	}
-	log.Printf("[WORDLE START] User: %s | Mode: DAILY | Word: %s", userID, word)
+}
+
+// startWordleGame posts the public board for a new game and saves it.
+func startWordleGame(s *discordgo.Session, channelID string, newState *database.WordleState) error {
+	newState.StartedAt = time.Now().Unix()
 
 	// Send Public Message
 	publicEmbed := buildPublicEmbed(newState)
@@ -258,7 +620,7 @@ func handleGuessCommand(s *discordgo.Session, i *discordgo.InteractionCreate, gu
 		},
 	}
 
-	msg, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+	msg, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
 		Embeds:     []*discordgo.MessageEmbed{publicEmbed},
 		Components: components,
 	})
@@ -270,20 +632,26 @@ func handleGuessCommand(s *discordgo.Session, i *discordgo.InteractionCreate, gu
 		newState.ChannelID = msg.ChannelID
 	}
 
-	if err := DB.SaveWordleState(newState); err != nil {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{Content: "Failed to start game.", Flags: discordgo.MessageFlagsEphemeral},
-		})
-		return
-	}
+	return DB.SaveWordleState(newState)
+}
 
-	// Check if a guess was provided
-	if guessArg != "" {
-		handleGuess(s, i, newState, guessArg)
-	} else {
-		respondWithGame(s, i.Interaction, newState)
+// wordleMode names the stats bucket for a game: "classic", "classic-hard", "6-letter", "6-letter-hard", "classic-es", ...
+func wordleMode(state *database.WordleState) string {
+	return wordleModeName(state.Lang, len([]rune(state.Word)), state.HardMode)
+}
+
+func wordleModeName(lang string, length int, hard bool) string {
+	mode := "classic"
+	if length != classicWordLength {
+		mode = fmt.Sprintf("%d-letter", length)
 	}
+	if hard {
+		mode += "-hard"
+	}
+	if lang != "" && lang != defaultWordleLang {
+		mode += "-" + lang
+	}
+	return mode
 }
 
 func handleGiveUpCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
@@ -310,8 +678,8 @@ func handleGiveUpCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 }
 
 func respondWithGame(s *discordgo.Session, interaction *discordgo.Interaction, state *database.WordleState) {
-	// If message ID is missing (legacy game), try to send a new public one
-	if state.MessageID == "" {
+	// If message ID is missing (legacy game), try to send a new public one. Races show progress on the race message instead.
+	if state.MessageID == "" && state.GameType != "RACE" {
 		publicEmbed := buildPublicEmbed(state)
 		components := []discordgo.MessageComponent{
 			discordgo.ActionsRow{
@@ -344,8 +712,15 @@ func respondWithGame(s *discordgo.Session, interaction *discordgo.Interaction, s
 	})
 }
 
-func respondWithStatsAndRandomOffer(s *discordgo.Session, interaction *discordgo.Interaction, userID string) {
-	stats, _ := DB.GetWordleStats(userID)
+func respondWithStatsAndRandomOffer(s *discordgo.Session, interaction *discordgo.Interaction, userID string, hard bool, lang string) {
+	stats, _ := DB.GetWordleStats(userID, wordleModeName(lang, classicWordLength, hard))
+	randomID := "wordle_start_random"
+	if hard {
+		randomID = "wordle_start_random_hard"
+	}
+	if lang != defaultWordleLang {
+		randomID += ":" + lang
+	}
 	embed := &discordgo.MessageEmbed{
 		Title: "Wordle - Daily Completed",
 		Color: 0x00FF00,
@@ -359,7 +734,7 @@ func respondWithStatsAndRandomOffer(s *discordgo.Session, interaction *discordgo
 				discordgo.Button{
 					Label:    "Play Random Wordle",
 					Style:    discordgo.SecondaryButton,
-					CustomID: "wordle_start_random",
+					CustomID: randomID,
 				},
 			},
 		},
@@ -378,8 +753,18 @@ func HandleWordleComponent(s *discordgo.Session, i *discordgo.InteractionCreate)
 	id := i.MessageComponentData().CustomID
 	userID := i.Member.User.ID
 
-	if id == "wordle_start_random" {
-		startRandomGame(s, i, userID)
+	if strings.HasPrefix(id, "wordle_start_random") {
+		// The language rides along after a colon, e.g. wordle_start_random_hard:es
+		base, lang, _ := strings.Cut(id, ":")
+		if lang == "" {
+			lang = defaultWordleLang
+		}
+		startRandomGame(s, i, userID, base == "wordle_start_random_hard", lang)
+		return
+	}
+
+	if strings.HasPrefix(id, "wordle_race_") {
+		handleWordleRaceButton(s, i, id)
 		return
 	}
 
@@ -415,13 +800,28 @@ func handleGuess(s *discordgo.Session, i *discordgo.InteractionCreate, state *da
 		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
 	})
 
-	guess = strings.ToUpper(guess)
-	if !IsValidWord(guess) {
+	guess = normalizeWordleWord(state.Lang, guess)
+	if length := len([]rune(state.Word)); len([]rune(guess)) != length {
+		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+			Content: &[]string{fmt.Sprintf("Your guess must be %d letters!", length)}[0],
+		})
+		return
+	}
+	if !IsValidWord(state.Lang, guess) {
 		s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 			Content: &[]string{"Not a valid word!"}[0],
 		})
 		return
 	}
+	if state.HardMode {
+		if err := CheckHardMode(guess, state.Guesses, state.Word); err != nil {
+			msg := err.Error()
+			s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+				Content: &[]string{"Hard mode: " + strings.ToUpper(msg[:1]) + msg[1:] + "."}[0],
+			})
+			return
+		}
+	}
 
 	// Update State
 	state.Guesses = append(state.Guesses, guess)
@@ -469,55 +869,37 @@ func handleGuess(s *discordgo.Session, i *discordgo.InteractionCreate, state *da
 	if won || lost {
 		finishWordleGame(s, i, state, won)
 	} else {
+		if state.GameType == "RACE" {
+			updateWordleRace(s, state)
+		}
 		// Silent success - delete the deferred response
 		s.InteractionResponseDelete(i.Interaction)
 	}
 }
 
-func startRandomGame(s *discordgo.Session, i *discordgo.InteractionCreate, userID string) {
-	word := GetRandomWord()
-	newState := &database.WordleState{
+func newRandomWordleState(userID string, length int, hard bool, lang string) *database.WordleState {
+	return &database.WordleState{
 		UserID:     userID,
 		GameType:   "RANDOM",
-		Word:       word,
+		Word:       GetRandomWord(lang, length),
 		Guesses:    []string{},
 		LastPlayed: time.Now().UTC().Format("2006-01-02"), // Not critical for random but good for keeping track
 		Completed:  false,
+		HardMode:   hard,
+		Lang:       lang,
 	}
-	log.Printf("[WORDLE START] User: %s | Mode: RANDOM | Word: %s", userID, word)
+}
+
+func startRandomGame(s *discordgo.Session, i *discordgo.InteractionCreate, userID string, hard bool, lang string) {
+	newState := newRandomWordleState(userID, classicWordLength, hard, lang)
+	log.Printf("[WORDLE START] User: %s | Mode: RANDOM (%s) | Word: %s", userID, wordleMode(newState), newState.Word)
 
 	// Ack the button click silently
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredMessageUpdate,
 	})
 
-	// Send Public Message
-	publicEmbed := buildPublicEmbed(newState)
-	components := []discordgo.MessageComponent{
-		discordgo.ActionsRow{
-			Components: []discordgo.MessageComponent{
-				discordgo.Button{
-					Label:    "Show Guesses",
-					Style:    discordgo.SecondaryButton,
-					CustomID: "wordle_show_guesses",
-				},
-			},
-		},
-	}
-
-	msg, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
-		Embeds:     []*discordgo.MessageEmbed{publicEmbed},
-		Components: components,
-	})
-
-	if err != nil {
-		fmt.Println("Error sending public wordle message:", err)
-	} else {
-		newState.MessageID = msg.ID
-		newState.ChannelID = msg.ChannelID
-	}
-
-	if err := DB.SaveWordleState(newState); err != nil {
+	if err := startWordleGame(s, i.ChannelID, newState); err != nil {
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{Content: "Failed to start game.", Flags: discordgo.MessageFlagsEphemeral},
@@ -528,32 +910,30 @@ func startRandomGame(s *discordgo.Session, i *discordgo.InteractionCreate, userI
 
 func finishWordleGame(s *discordgo.Session, i *discordgo.InteractionCreate, state *database.WordleState, won bool) {
 	reward := 0
-	if won {
+	if won && state.GameType != "RACE" {
 		reward = CalculateWordleReward(len(state.Guesses))
+		if state.GameType == "ARCHIVE" {
+			reward = archiveWordleReward(len(state.Guesses))
+		}
 		DB.AddBalance(state.UserID, reward)
 	}
 	log.Printf("[WORDLE FINISH] User: %s | Won: %v | Guesses: %d | Reward: %d", state.UserID, won, len(state.Guesses), reward)
 
-	// Update Stats
-	stats, _ := DB.GetWordleStats(state.UserID)
-	if stats == nil {
-		stats = &database.WordleStats{UserID: state.UserID, Distribution: make(map[int]int)}
-	}
-	if stats.Distribution == nil {
-		stats.Distribution = make(map[int]int)
-	}
-	stats.GamesPlayed++
-	if won {
-		stats.GamesWon++
-		stats.CurrentStreak++
-		if stats.CurrentStreak > stats.MaxStreak {
-			stats.MaxStreak = stats.CurrentStreak
+	switch state.GameType {
+	case "DAILY":
+		recordDailyWordleResult(i.GuildID, state, won)
+		updateWordleStats(state, won)
+	case "RACE":
+		// Races pay out of the escrowed wagers and don't count toward stats
+		updateWordleRace(s, state)
+	case "ARCHIVE":
+		// Archive puzzles are tracked on their own so the daily streak is untouched
+		if err := DB.RecordWordleArchive(state.UserID, state.LastPlayed, state.Lang, won, len(state.Guesses)); err != nil {
+			log.Printf("[WORDLE] Failed to record archive result for %s: %v", state.UserID, err)
 		}
-		stats.Distribution[len(state.Guesses)]++
-	} else {
-		stats.CurrentStreak = 0
+	default:
+		updateWordleStats(state, won)
 	}
-	DB.UpdateWordleStats(stats)
 
 	// Update Public Message
 	if state.MessageID != "" && state.ChannelID != "" {
@@ -581,7 +961,15 @@ func finishWordleGame(s *discordgo.Session, i *discordgo.InteractionCreate, stat
 	privateEmbed.Title = "Wordle - Finished"
 
 	var statusMsg string
-	if won {
+	if state.GameType == "RACE" {
+		if won {
+			privateEmbed.Color = 0x00FF00
+			statusMsg = fmt.Sprintf("Solved in %d! The word was **%s**. Check the race board for the result.\n\n", len(state.Guesses), state.Word)
+		} else {
+			privateEmbed.Color = 0xFF0000
+			statusMsg = fmt.Sprintf("Out of guesses. The word was **%s**.\n\n", state.Word)
+		}
+	} else if won {
 		privateEmbed.Color = 0x00FF00
 		statusMsg = fmt.Sprintf("You Won! The word was **%s**.\nReward: **$%d**\n\n", state.Word, reward)
 	} else {
@@ -607,6 +995,28 @@ func finishWordleGame(s *discordgo.Session, i *discordgo.InteractionCreate, stat
 	}
 }
 
+func updateWordleStats(state *database.WordleState, won bool) {
+	stats, _ := DB.GetWordleStats(state.UserID, wordleMode(state))
+	if stats == nil {
+		stats = &database.WordleStats{UserID: state.UserID, Mode: wordleMode(state), Distribution: make(map[int]int)}
+	}
+	if stats.Distribution == nil {
+		stats.Distribution = make(map[int]int)
+	}
+	stats.GamesPlayed++
+	if won {
+		stats.GamesWon++
+		stats.CurrentStreak++
+		if stats.CurrentStreak > stats.MaxStreak {
+			stats.MaxStreak = stats.CurrentStreak
+		}
+		stats.Distribution[len(state.Guesses)]++
+	} else {
+		stats.CurrentStreak = 0
+	}
+	DB.UpdateWordleStats(stats)
+}
+
 func buildPrivateEmbed(state *database.WordleState) *discordgo.MessageEmbed {
 	var sb strings.Builder
 	for _, guess := range state.Guesses {
@@ -625,14 +1035,12 @@ func buildPrivateEmbed(state *database.WordleState) *discordgo.MessageEmbed {
 	}
 
 	// Fill remaining rows
+	emptyRow := strings.TrimSpace(strings.Repeat("⬜ ", len([]rune(state.Word)))) + "\n"
 	for i := len(state.Guesses); i < 6; i++ {
-		sb.WriteString("⬜ ⬜ ⬜ ⬜ ⬜\n")
+		sb.WriteString(emptyRow)
 	}
 
-	title := "Wordle - Daily"
-	if state.GameType == "RANDOM" {
-		title = "Wordle - Random"
-	}
+	title := wordleTitle(state)
 
 	return &discordgo.MessageEmbed{
 		Title:       title,
@@ -661,14 +1069,12 @@ func buildPublicEmbed(state *database.WordleState) *discordgo.MessageEmbed {
 	}
 
 	// Fill remaining rows
+	emptyRow := strings.TrimSpace(strings.Repeat("⬜ ", len([]rune(state.Word)))) + "\n"
 	for i := len(state.Guesses); i < 6; i++ {
-		sb.WriteString("⬜ ⬜ ⬜ ⬜ ⬜\n")
+		sb.WriteString(emptyRow)
 	}
 
-	title := "Wordle - Daily"
-	if state.GameType == "RANDOM" {
-		title = "Wordle - Random"
-	}
+	title := wordleTitle(state)
 
 	description := fmt.Sprintf("<@%s>\n\n%s", state.UserID, sb.String())
 
@@ -679,3 +1085,25 @@ func buildPublicEmbed(state *database.WordleState) *discordgo.MessageEmbed {
 		Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Attempt %d/6", len(state.Guesses))},
 	}
 }
+
+func wordleTitle(state *database.WordleState) string {
+	title := "Wordle - Daily"
+	switch state.GameType {
+	case "RANDOM":
+		title = "Wordle - Random"
+	case "RACE":
+		title = "Wordle - Race"
+	case "ARCHIVE":
+		title = "Wordle - Archive " + state.LastPlayed
+	}
+	if length := len([]rune(state.Word)); length != classicWordLength {
+		title += fmt.Sprintf(" (%d letters)", length)
+	}
+	if state.HardMode {
+		title += " - Hard"
+	}
+	if state.Lang != "" && state.Lang != defaultWordleLang {
+		title += " - " + wordleLanguageName(state.Lang)
+	}
+	return title
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"soosa/internal/database"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// wordleArchiveDays is how far back the archive goes.
+const wordleArchiveDays = 365
+
+// archiveWordleReward is half the daily reward, so catching up is worth it without beating playing on the day.
+func archiveWordleReward(attempts int) int {
+	return CalculateWordleReward(attempts) / 2
+}
+
+// parseWordleArchiveDate checks that date is a past day within the archive window.
+func parseWordleArchiveDate(date string, now time.Time) (time.Time, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("dates look like 2024-03-14")
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	if !day.Before(today) {
+		return time.Time{}, fmt.Errorf("only past puzzles are in the archive, play today's with `/wordle guess`")
+	}
+	if day.Before(today.AddDate(0, 0, -wordleArchiveDays)) {
+		return time.Time{}, fmt.Errorf("the archive only goes back %d days", wordleArchiveDays)
+	}
+	return day, nil
+}
+
+func handleWordleArchiveCommand(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	userID := i.Member.User.ID
+
+	var dateArg string
+	hard := false
+	lang := defaultWordleLang
+	for _, opt := range options {
+		switch opt.Name {
+		case "date":
+			dateArg = opt.StringValue()
+		case "hard":
+			hard = opt.BoolValue()
+		case "lang":
+			lang = opt.StringValue()
+		}
+	}
+
+	day, err := parseWordleArchiveDate(dateArg, time.Now())
+	if err != nil {
+		respondError(s, i, fmt.Sprintf("Can't open that puzzle: %v.", err))
+		return
+	}
+	date := day.Format("2006-01-02")
+
+	state, err := DB.GetWordleState(userID)
+	if err != nil {
+		respondError(s, i, "Error retrieving game state.")
+		return
+	}
+	if state != nil && !state.Completed {
+		respondError(s, i, "Finish or give up your current game first.")
+		return
+	}
+
+	playedDaily, _ := DB.HasWordleResult(userID, date, lang)
+	playedArchive, _ := DB.HasWordleArchive(userID, date, lang)
+	if playedDaily || playedArchive {
+		respondError(s, i, fmt.Sprintf("You already played the puzzle for %s.", date))
+		return
+	}
+
+	if !wordleLengthAvailable(lang, classicWordLength) {
+		respondError(s, i, fmt.Sprintf("Wordle isn't available in %s.", wordleLanguageName(lang)))
+		return
+	}
+
+	// LastPlayed holds the puzzle's date so the finish can record it
+	newState := &database.WordleState{
+		UserID:     userID,
+		GameType:   "ARCHIVE",
+		Word:       GetGuildDailyWord(i.GuildID, lang, day),
+		Guesses:    []string{},
+		LastPlayed: date,
+		Completed:  false,
+		HardMode:   hard,
+		Lang:       lang,
+	}
+	log.Printf("[WORDLE START] User: %s | Mode: %s (%s) | Date: %s | Word: %s", userID, newState.GameType, wordleMode(newState), date, newState.Word)
+
+	if err := startWordleGame(s, i.ChannelID, newState); err != nil {
+		respondError(s, i, "Failed to start game.")
+		return
+	}
+	respondWithGame(s, i.Interaction, newState)
+}
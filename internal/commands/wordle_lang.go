@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultWordleLang is the language of the classic lists and of server custom word lists.
+const defaultWordleLang = "en"
+
+// wordleLanguage is a supported Wordle language. Keep holds the letters that count as their own letter rather than an accented form of another.
+type wordleLanguage struct {
+	Code string
+	Name string
+	Keep string
+}
+
+// wordleLanguages are the languages offered by the lang options. Non-English lists are wordlist_answers_<code>.txt and wordlist_allowed_<code>.txt beside the classic lists.
+var wordleLanguages = []wordleLanguage{
+	{Code: "en", Name: "English"},
+	{Code: "es", Name: "Español", Keep: "Ñ"},
+	{Code: "de", Name: "Deutsch", Keep: "ÄÖÜ"},
+}
+
+// wordleAccentFolds maps accented capitals to their plain letter, so players don't have to type accents.
+var wordleAccentFolds = map[rune]rune{
+	'Á': 'A', 'À': 'A', 'Â': 'A', 'Ä': 'A', 'Ã': 'A', 'Å': 'A',
+	'É': 'E', 'È': 'E', 'Ê': 'E', 'Ë': 'E',
+	'Í': 'I', 'Ì': 'I', 'Î': 'I', 'Ï': 'I',
+	'Ó': 'O', 'Ò': 'O', 'Ô': 'O', 'Ö': 'O', 'Õ': 'O',
+	'Ú': 'U', 'Ù': 'U', 'Û': 'U', 'Ü': 'U',
+	'Ñ': 'N', 'Ç': 'C',
+}
+
+// wordleLanguageChoices are the lang option choices shared by the Wordle subcommands.
+func wordleLanguageChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(wordleLanguages))
+	for _, l := range wordleLanguages {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: l.Name, Value: l.Code})
+	}
+	return choices
+}
+
+// getWordleLanguage returns the language for code, or nil if it isn't supported.
+func getWordleLanguage(code string) *wordleLanguage {
+	for idx := range wordleLanguages {
+		if wordleLanguages[idx].Code == code {
+			return &wordleLanguages[idx]
+		}
+	}
+	return nil
+}
+
+// wordleLanguageName returns the display name for a language code.
+func wordleLanguageName(code string) string {
+	if l := getWordleLanguage(code); l != nil {
+		return l.Name
+	}
+	return code
+}
+
+// normalizeWordleWord uppercases the word and strips accents that aren't letters of their own in the language, e.g. "árbol" becomes "ARBOL" in Spanish but "Ñ" is kept.
+func normalizeWordleWord(lang, word string) string {
+	word = strings.ToUpper(strings.TrimSpace(word))
+	keep := ""
+	if l := getWordleLanguage(lang); l != nil {
+		keep = l.Keep
+	}
+	return strings.Map(func(r rune) rune {
+		if plain, ok := wordleAccentFolds[r]; ok && !strings.ContainsRune(keep, r) {
+			return plain
+		}
+		return r
+	}, word)
+}
@@ -0,0 +1,426 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"soosa/internal/database"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type WordleRaceStatus string
+
+const (
+	RacePending  WordleRaceStatus = "PENDING"
+	RaceActive   WordleRaceStatus = "ACTIVE"
+	RaceFinished WordleRaceStatus = "FINISHED"
+)
+
+// wordleRacer is one side of a race as shown on the public board.
+type wordleRacer struct {
+	UserID     string
+	Grid       string
+	Attempts   int
+	Done       bool
+	Won        bool
+	FinishedAt time.Time
+}
+
+type WordleRace struct {
+	MessageID string
+	ChannelID string
+	Word      string
+	Wager     int
+	Status    WordleRaceStatus
+	Racers    []*wordleRacer // Challenger first
+	StartedAt time.Time
+	Timer     *time.Timer
+	Result    string
+}
+
+var (
+	activeWordleRaces  = make(map[string]*WordleRace) // By public message ID
+	wordleRacesByUser  = make(map[string]*WordleRace)
+	wordleRaceMutex    sync.Mutex
+	wordleRaceAccept   = 2 * time.Minute
+	wordleRaceDuration = 10 * time.Minute
+)
+
+func handleWordleRaceCommand(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	challengerID := i.Member.User.ID
+	var opponent *discordgo.User
+	wager := 0
+	for _, opt := range options {
+		switch opt.Name {
+		case "opponent":
+			opponent = opt.UserValue(s)
+		case "wager":
+			wager = int(opt.IntValue())
+		}
+	}
+
+	if opponent == nil || opponent.ID == challengerID || opponent.Bot {
+		respondError(s, i, "Pick another player to race.")
+		return
+	}
+	if wager < 0 {
+		respondError(s, i, "Wager cannot be negative.")
+		return
+	}
+	if !wordleLengthAvailable(defaultWordleLang, classicWordLength) {
+		respondError(s, i, "Wordle isn't available.")
+		return
+	}
+
+	wordleRaceMutex.Lock()
+	_, challengerRacing := wordleRacesByUser[challengerID]
+	_, opponentRacing := wordleRacesByUser[opponent.ID]
+	wordleRaceMutex.Unlock()
+	if challengerRacing || opponentRacing {
+		respondError(s, i, "One of you is already in a race.")
+		return
+	}
+	if state, _ := DB.GetWordleState(challengerID); state != nil && !state.Completed {
+		respondError(s, i, "Finish or give up your current Wordle first.")
+		return
+	}
+
+	if wager > 0 {
+		bal, err := DB.GetBalance(challengerID)
+		if err != nil {
+			respondError(s, i, "Database error.")
+			return
+		}
+		if bal < wager {
+			respondError(s, i, "Insufficient funds.")
+			return
+		}
+		if err := DB.AddBalance(challengerID, -wager); err != nil {
+			respondError(s, i, "Transaction failed.")
+			return
+		}
+	}
+
+	race := &WordleRace{
+		Wager:  wager,
+		Status: RacePending,
+		Racers: []*wordleRacer{{UserID: challengerID}, {UserID: opponent.ID}},
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    fmt.Sprintf("<@%s>", opponent.ID),
+			Embeds:     []*discordgo.MessageEmbed{buildWordleRaceEmbed(race)},
+			Components: createWordleRaceButtons(),
+		},
+	})
+	if err != nil {
+		DB.AddBalance(challengerID, wager)
+		return
+	}
+
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		DB.AddBalance(challengerID, wager)
+		return
+	}
+	race.MessageID = msg.ID
+	race.ChannelID = msg.ChannelID
+	log.Printf("[WORDLE RACE] %s challenged %s | Wager: %d", challengerID, opponent.ID, wager)
+
+	wordleRaceMutex.Lock()
+	activeWordleRaces[race.MessageID] = race
+	wordleRacesByUser[challengerID] = race
+	wordleRacesByUser[opponent.ID] = race
+	race.Timer = time.AfterFunc(wordleRaceAccept, func() {
+		wordleRaceMutex.Lock()
+		defer wordleRaceMutex.Unlock()
+		if race.Status == RacePending {
+			cancelWordleRace(s, race, "The challenge expired.")
+		}
+	})
+	wordleRaceMutex.Unlock()
+}
+
+func createWordleRaceButtons() []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{Label: "Accept", Style: discordgo.SuccessButton, CustomID: "wordle_race_accept"},
+				discordgo.Button{Label: "Decline", Style: discordgo.DangerButton, CustomID: "wordle_race_decline"},
+			},
+		},
+	}
+}
+
+func handleWordleRaceButton(s *discordgo.Session, i *discordgo.InteractionCreate, id string) {
+	wordleRaceMutex.Lock()
+	defer wordleRaceMutex.Unlock()
+
+	race, exists := activeWordleRaces[i.Message.ID]
+	if !exists || race.Status != RacePending {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: "This challenge is no longer open.", Flags: discordgo.MessageFlagsEphemeral},
+		})
+		return
+	}
+
+	userID := i.Member.User.ID
+	challenger, opponent := race.Racers[0], race.Racers[1]
+	if userID != opponent.UserID && !(id == "wordle_race_decline" && userID == challenger.UserID) {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: "This challenge isn't for you.", Flags: discordgo.MessageFlagsEphemeral},
+		})
+		return
+	}
+
+	if id == "wordle_race_decline" {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{Type: discordgo.InteractionResponseDeferredMessageUpdate})
+		reason := "The challenge was declined."
+		if userID == challenger.UserID {
+			reason = "The challenge was withdrawn."
+		}
+		cancelWordleRace(s, race, reason)
+		return
+	}
+
+	// Accept
+	fail := func(msg string) {
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{Content: msg, Flags: discordgo.MessageFlagsEphemeral},
+		})
+	}
+	if state, _ := DB.GetWordleState(opponent.UserID); state != nil && !state.Completed {
+		fail("Finish or give up your current Wordle first.")
+		return
+	}
+	if state, _ := DB.GetWordleState(challenger.UserID); state != nil && !state.Completed {
+		fail("Your challenger started another Wordle in the meantime.")
+		return
+	}
+	if race.Wager > 0 {
+		bal, err := DB.GetBalance(opponent.UserID)
+		if err != nil {
+			fail("Database error.")
+			return
+		}
+		if bal < race.Wager {
+			fail("Insufficient funds.")
+			return
+		}
+		if err := DB.AddBalance(opponent.UserID, -race.Wager); err != nil {
+			fail("Transaction failed.")
+			return
+		}
+	}
+
+	race.Word = GetRandomWord(defaultWordleLang, classicWordLength)
+	race.Status = RaceActive
+	race.StartedAt = time.Now()
+	today := race.StartedAt.UTC().Format("2006-01-02")
+	for _, r := range race.Racers {
+		// Racers get no public board of their own; progress lives on the race message
+		DB.SaveWordleState(&database.WordleState{
+			UserID:     r.UserID,
+			GameType:   "RACE",
+			Lang:       defaultWordleLang,
+			Word:       race.Word,
+			Guesses:    []string{},
+			LastPlayed: today,
+			StartedAt:  race.StartedAt.Unix(),
+		})
+	}
+	log.Printf("[WORDLE RACE] %s vs %s started | Word: %s", challenger.UserID, opponent.UserID, race.Word)
+
+	if race.Timer != nil {
+		race.Timer.Stop()
+	}
+	race.Timer = time.AfterFunc(wordleRaceDuration, func() {
+		wordleRaceMutex.Lock()
+		defer wordleRaceMutex.Unlock()
+		if race.Status == RaceActive {
+			// Anyone still guessing runs out of time
+			for _, r := range race.Racers {
+				if !r.Done {
+					r.Done = true
+					if state, _ := DB.GetWordleState(r.UserID); state != nil && state.GameType == "RACE" && !state.Completed {
+						state.Completed = true
+						DB.SaveWordleState(state)
+					}
+				}
+			}
+			settleWordleRace(s, race)
+		}
+	})
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    "",
+			Embeds:     []*discordgo.MessageEmbed{buildWordleRaceEmbed(race)},
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+}
+
+// updateWordleRace records a racer's latest guess and settles the race once both are done.
+func updateWordleRace(s *discordgo.Session, state *database.WordleState) {
+	wordleRaceMutex.Lock()
+	defer wordleRaceMutex.Unlock()
+
+	race, exists := wordleRacesByUser[state.UserID]
+	if !exists || race.Status != RaceActive {
+		return
+	}
+
+	for _, r := range race.Racers {
+		if r.UserID != state.UserID || r.Done {
+			continue
+		}
+		r.Grid = wordleGrid(state)
+		r.Attempts = len(state.Guesses)
+		if state.Completed {
+			r.Done = true
+			r.Won = r.Attempts > 0 && state.Guesses[r.Attempts-1] == state.Word
+			r.FinishedAt = time.Now()
+		}
+	}
+
+	for _, r := range race.Racers {
+		if !r.Done {
+			editWordleRaceMessage(s, race)
+			return
+		}
+	}
+	settleWordleRace(s, race)
+}
+
+// settleWordleRace pays out the escrow: fewest guesses wins, then fastest. Callers must hold wordleRaceMutex.
+func settleWordleRace(s *discordgo.Session, race *WordleRace) {
+	if race.Timer != nil {
+		race.Timer.Stop()
+	}
+	race.Status = RaceFinished
+
+	var winner *wordleRacer
+	for _, r := range race.Racers {
+		if !r.Won {
+			continue
+		}
+		if winner == nil || r.Attempts < winner.Attempts || (r.Attempts == winner.Attempts && r.FinishedAt.Before(winner.FinishedAt)) {
+			winner = r
+		}
+	}
+
+	pot := race.Wager * len(race.Racers)
+	if winner == nil {
+		for _, r := range race.Racers {
+			DB.AddBalance(r.UserID, race.Wager)
+		}
+		race.Result = fmt.Sprintf("Nobody solved it. The word was **%s**. Wagers refunded.", race.Word)
+	} else {
+		DB.AddBalance(winner.UserID, pot)
+		race.Result = fmt.Sprintf("🏆 <@%s> wins in %d guesses (%s)! The word was **%s**.", winner.UserID, winner.Attempts, formatSolveTime(int(winner.FinishedAt.Sub(race.StartedAt).Seconds())), race.Word)
+		if pot > 0 {
+			race.Result += fmt.Sprintf("\nWinnings: **$%d**", pot)
+		}
+	}
+	log.Printf("[WORDLE RACE] Race %s finished | %s", race.MessageID, race.Result)
+
+	editWordleRaceMessage(s, race)
+	removeWordleRace(race)
+}
+
+// cancelWordleRace refunds the challenger for a race that never started. Callers must hold wordleRaceMutex.
+func cancelWordleRace(s *discordgo.Session, race *WordleRace, reason string) {
+	if race.Timer != nil {
+		race.Timer.Stop()
+	}
+	race.Status = RaceFinished
+	if race.Wager > 0 {
+		DB.AddBalance(race.Racers[0].UserID, race.Wager)
+	}
+	race.Result = reason
+	log.Printf("[WORDLE RACE] Race %s cancelled | %s", race.MessageID, reason)
+
+	editWordleRaceMessage(s, race)
+	removeWordleRace(race)
+}
+
+func removeWordleRace(race *WordleRace) {
+	delete(activeWordleRaces, race.MessageID)
+	for _, r := range race.Racers {
+		if wordleRacesByUser[r.UserID] == race {
+			delete(wordleRacesByUser, r.UserID)
+		}
+	}
+}
+
+func editWordleRaceMessage(s *discordgo.Session, race *WordleRace) {
+	content := ""
+	s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:         race.MessageID,
+		Channel:    race.ChannelID,
+		Content:    &content,
+		Embeds:     &[]*discordgo.MessageEmbed{buildWordleRaceEmbed(race)},
+		Components: &[]discordgo.MessageComponent{},
+	})
+}
+
+func buildWordleRaceEmbed(race *WordleRace) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: "Wordle Race",
+		Color: 0x3498db,
+	}
+
+	challenger, opponent := race.Racers[0], race.Racers[1]
+	switch race.Status {
+	case RacePending:
+		embed.Description = fmt.Sprintf("<@%s> challenges <@%s> to a Wordle race!", challenger.UserID, opponent.UserID)
+		if race.Wager > 0 {
+			embed.Description += fmt.Sprintf("\nWager: **$%d** each, winner takes all.", race.Wager)
+		}
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Expires in %s", wordleRaceAccept)}
+		return embed
+	case RaceActive:
+		embed.Description = fmt.Sprintf("Same word, first to solve in the fewest guesses wins. Play with `/wordle guess`.\nTime limit: %s", wordleRaceDuration)
+		if race.Wager > 0 {
+			embed.Description += fmt.Sprintf("\nPot: **$%d**", race.Wager*len(race.Racers))
+		}
+	case RaceFinished:
+		embed.Title = "Wordle Race - Finished"
+		embed.Color = 0xF1C40F
+		embed.Description = race.Result
+		if race.Word == "" {
+			// Never started
+			embed.Color = 0x95A5A6
+			return embed
+		}
+	}
+
+	for _, r := range race.Racers {
+		status := fmt.Sprintf("%d/6", r.Attempts)
+		if r.Done && r.Won {
+			status = "✅ " + status
+		} else if r.Done {
+			status = "❌ " + status
+		}
+		grid := r.Grid
+		if grid == "" {
+			grid = "No guesses yet"
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   status,
+			Value:  fmt.Sprintf("<@%s>\n%s", r.UserID, grid),
+			Inline: true,
+		})
+	}
+	return embed
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// wordleReloadInterval is how often the word files are checked for changes.
+const wordleReloadInterval = 30 * time.Second
+
+var (
+	// The paths the word lists were last loaded from, so they can be reloaded without a restart. Guarded by wordListsMutex.
+	wordleAnswersPath string
+	wordleAllowedPath string
+	wordlePacksDir    string
+)
+
+// wordleFilesSignature summarises the size and modification time of every word file, so any edit, addition or removal changes it.
+func wordleFilesSignature(answersPath, allowedPath, packsDir string) string {
+	var paths []string
+	for _, lang := range wordleLanguages {
+		for _, length := range wordleLengths {
+			paths = append(paths, wordListPath(answersPath, lang.Code, length), wordListPath(allowedPath, lang.Code, length))
+		}
+	}
+	if packsDir != "" {
+		packs, _ := filepath.Glob(filepath.Join(packsDir, "*.txt"))
+		sort.Strings(packs)
+		paths = append(paths, packs...)
+	}
+
+	var sb strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(&sb, "%s:missing;", path)
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String()
+}
+
+// ReloadWordleWords reloads the word lists and themed packs from the paths they were last loaded from. Games in progress keep their word.
+func ReloadWordleWords() error {
+	wordListsMutex.RLock()
+	answersPath, allowedPath, packsDir := wordleAnswersPath, wordleAllowedPath, wordlePacksDir
+	wordListsMutex.RUnlock()
+
+	if answersPath == "" || allowedPath == "" {
+		return fmt.Errorf("word lists were never loaded")
+	}
+	if err := LoadWordleWords(answersPath, allowedPath); err != nil {
+		return err
+	}
+	// Packs are validated against the allowed list, so they are reloaded after it
+	if packsDir != "" {
+		if err := LoadWordlePacks(packsDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartWordleWordWatcher polls the word files and reloads them when any of them change, so dictionaries can be updated without a restart.
+func StartWordleWordWatcher() {
+	go func() {
+		wordListsMutex.RLock()
+		answersPath, allowedPath, packsDir := wordleAnswersPath, wordleAllowedPath, wordlePacksDir
+		wordListsMutex.RUnlock()
+		if answersPath == "" {
+			return
+		}
+
+		last := wordleFilesSignature(answersPath, allowedPath, packsDir)
+		for {
+			time.Sleep(wordleReloadInterval)
+
+			current := wordleFilesSignature(answersPath, allowedPath, packsDir)
+			if current == last {
+				continue
+			}
+			last = current
+
+			if err := ReloadWordleWords(); err != nil {
+				log.Printf("[WORDLE] Failed to reload word lists, keeping the old ones: %v", err)
+				continue
+			}
+			log.Printf("[WORDLE] Word lists changed on disk and were reloaded")
+		}
+	}()
+}
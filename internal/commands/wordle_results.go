@@ -0,0 +1,291 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"soosa/internal/database"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// wordleResultsPerEmbed keeps the nightly post under Discord's 25-field embed limit.
+const wordleResultsPerEmbed = 20
+
+// wordleGrid renders the guesses as emoji squares only, so it can be shared without spoiling the word.
+func wordleGrid(state *database.WordleState) string {
+	var sb strings.Builder
+	for _, guess := range state.Guesses {
+		for _, status := range EvaluateGuess(guess, state.Word) {
+			switch status {
+			case StatusGreen:
+				sb.WriteString("🟩")
+			case StatusYellow:
+				sb.WriteString("🟨")
+			default:
+				sb.WriteString("⬛")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func recordDailyWordleResult(guildID string, state *database.WordleState, won bool) {
+	if guildID == "" {
+		return
+	}
+
+	solveSeconds := 0
+	if state.StartedAt > 0 {
+		solveSeconds = int(time.Now().Unix() - state.StartedAt)
+	}
+
+	err := DB.RecordWordleResult(&database.WordleResult{
+		GuildID:      guildID,
+		UserID:       state.UserID,
+		Date:         state.LastPlayed,
+		Lang:         state.Lang,
+		Won:          won,
+		Attempts:     len(state.Guesses),
+		SolveSeconds: solveSeconds,
+		Grid:         wordleGrid(state),
+	})
+	if err != nil {
+		log.Printf("[WORDLE RESULTS] Failed to record result for %s: %v", state.UserID, err)
+	}
+}
+
+// formatSolveTime renders seconds as "1m 05s" (or "2h 03m" for long games).
+func formatSolveTime(seconds int) string {
+	d := time.Duration(seconds) * time.Second
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm %02ds", int(d.Minutes()), seconds%60)
+}
+
+func formatWordleAttempts(r database.WordleResult) string {
+	if !r.Won {
+		return "X/6"
+	}
+	return fmt.Sprintf("%d/6", r.Attempts)
+}
+
+func handleWordleLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate, period, lang string) {
+	now := time.Now().UTC()
+	today := now.Format("2006-01-02")
+
+	embed := &discordgo.MessageEmbed{Color: 0x3498db}
+	titleSuffix := ""
+	if lang != defaultWordleLang {
+		titleSuffix = " (" + wordleLanguageName(lang) + ")"
+	}
+
+	if period == "day" {
+		all, err := DB.GetWordleResults(i.GuildID, today)
+		if err != nil {
+			log.Printf("[WORDLE RESULTS] Leaderboard error: %v", err)
+			respondError(s, i, "Failed to load the leaderboard.")
+			return
+		}
+		results := wordleResultsByLang(all)[lang]
+
+		embed.Title = "Wordle Leaderboard - Today" + titleSuffix
+		if len(results) == 0 {
+			embed.Description = "Nobody has finished today's Wordle yet."
+		}
+		var sb strings.Builder
+		for rank, r := range results {
+			if rank == 10 {
+				break
+			}
+			line := fmt.Sprintf("**%d.** <@%s> - %s", rank+1, r.UserID, formatWordleAttempts(r))
+			if r.Won {
+				line += " in " + formatSolveTime(r.SolveSeconds)
+			}
+			sb.WriteString(line + "\n")
+		}
+		embed.Description += sb.String()
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("%d played today", len(results))}
+		respondEmbed(s, i, embed)
+		return
+	}
+
+	since := ""
+	embed.Title = "Wordle Leaderboard - All Time"
+	if period == "week" {
+		since = now.AddDate(0, 0, -6).Format("2006-01-02")
+		embed.Title = "Wordle Leaderboard - Last 7 Days"
+	}
+
+	embed.Title += titleSuffix
+
+	entries, err := DB.GetWordleLeaderboard(i.GuildID, lang, since, 10)
+	if err != nil {
+		log.Printf("[WORDLE RESULTS] Leaderboard error: %v", err)
+		respondError(s, i, "Failed to load the leaderboard.")
+		return
+	}
+
+	if len(entries) == 0 {
+		embed.Description = "No daily results yet."
+	}
+	var sb strings.Builder
+	for rank, e := range entries {
+		sb.WriteString(fmt.Sprintf("**%d.** <@%s> - %d/%d won", rank+1, e.UserID, e.Won, e.Played))
+		if e.Won > 0 {
+			sb.WriteString(fmt.Sprintf(", avg %.2f guesses, %s total", e.AvgAttempts, formatSolveTime(e.SolveSeconds)))
+		}
+		sb.WriteString("\n")
+	}
+	embed.Description += sb.String()
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: "Ranked by wins, then average guesses, then total solve time"}
+	respondEmbed(s, i, embed)
+}
+
+func handleWordleAdminCommand(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if !hasPermission(i.Member.User.ID, "admin.wordle") {
+		respondError(s, i, "You do not have permission to use this command.")
+		return
+	}
+	if len(options) == 0 {
+		return
+	}
+
+	switch options[0].Name {
+	case "wordlist":
+		handleWordleWordListCommand(s, i, options[0].Options)
+	case "results-channel":
+		channelID := ""
+		if len(options[0].Options) > 0 {
+			channelID = options[0].Options[0].ChannelValue(s).ID
+		}
+		if err := DB.SetWordleResultsChannel(i.GuildID, channelID); err != nil {
+			log.Printf("[WORDLE RESULTS] Failed to set results channel: %v", err)
+			respondError(s, i, "Database error.")
+			return
+		}
+		log.Printf("[WORDLE RESULTS] Guild %s results channel set to '%s' by %s", i.GuildID, channelID, i.Member.User.ID)
+		if channelID == "" {
+			respondSuccess(s, i, "Nightly Wordle results are turned off.")
+			return
+		}
+		respondSuccess(s, i, fmt.Sprintf("Daily Wordle results will be posted in <#%s> at midnight UTC.", channelID))
+	}
+}
+
+// StartWordleResultsScheduler posts the previous day's results to every configured channel just after midnight UTC. It catches up on a missed post at startup.
+func StartWordleResultsScheduler(s *discordgo.Session) {
+	go func() {
+		for {
+			postWordleResults(s, time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02"))
+
+			now := time.Now().UTC()
+			midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 5, 0, time.UTC)
+			time.Sleep(midnight.Sub(now))
+		}
+	}()
+}
+
+func postWordleResults(s *discordgo.Session, date string) {
+	channels, err := DB.GetWordleResultsChannels()
+	if err != nil {
+		log.Printf("[WORDLE RESULTS] Failed to load results channels: %v", err)
+		return
+	}
+
+	for _, c := range channels {
+		if c.LastPosted >= date {
+			continue
+		}
+
+		results, err := DB.GetWordleResults(c.GuildID, date)
+		if err != nil {
+			log.Printf("[WORDLE RESULTS] Failed to load results for guild %s: %v", c.GuildID, err)
+			continue
+		}
+
+		posted, err := DB.GetWordleResultsPostedLangs(c.GuildID, date)
+		if err != nil {
+			log.Printf("[WORDLE RESULTS] Failed to load posted languages for guild %s: %v", c.GuildID, err)
+			continue
+		}
+
+		failed := false
+		byLang := wordleResultsByLang(results)
+		for _, lang := range wordleLanguages {
+			if len(byLang[lang.Code]) == 0 || posted[lang.Code] {
+				continue
+			}
+			if err := sendWordleResults(s, c.ChannelID, date, lang.Code, byLang[lang.Code]); err != nil {
+				log.Printf("[WORDLE RESULTS] Failed to post results to %s: %v", c.ChannelID, err)
+				failed = true
+				break
+			}
+			if err := DB.MarkWordleResultsLangPosted(c.GuildID, date, lang.Code); err != nil {
+				log.Printf("[WORDLE RESULTS] Failed to record posted %s results for guild %s: %v", lang.Code, c.GuildID, err)
+			}
+		}
+		if failed {
+			continue
+		}
+		DB.MarkWordleResultsPosted(c.GuildID, date)
+		log.Printf("[WORDLE RESULTS] Posted %d results for %s to guild %s", len(results), date, c.GuildID)
+	}
+}
+
+// wordleResultsByLang splits a day's results by language, keeping each group's ranking order.
+func wordleResultsByLang(results []database.WordleResult) map[string][]database.WordleResult {
+	byLang := make(map[string][]database.WordleResult)
+	for _, r := range results {
+		byLang[r.Lang] = append(byLang[r.Lang], r)
+	}
+	return byLang
+}
+
+func sendWordleResults(s *discordgo.Session, channelID, date, lang string, results []database.WordleResult) error {
+	won := 0
+	for _, r := range results {
+		if r.Won {
+			won++
+		}
+	}
+
+	for start := 0; start < len(results); start += wordleResultsPerEmbed {
+		end := start + wordleResultsPerEmbed
+		if end > len(results) {
+			end = len(results)
+		}
+
+		embed := &discordgo.MessageEmbed{Color: 0x00FF00}
+		if start == 0 {
+			embed.Title = fmt.Sprintf("Daily Wordle Results - %s", date)
+			if lang != defaultWordleLang {
+				embed.Title += " (" + wordleLanguageName(lang) + ")"
+			}
+			embed.Description = fmt.Sprintf("%d played, %d solved.", len(results), won)
+		}
+		for rank, r := range results[start:end] {
+			name := fmt.Sprintf("#%d - %s", start+rank+1, formatWordleAttempts(r))
+			if r.Won {
+				name += " - " + formatSolveTime(r.SolveSeconds)
+			}
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   name,
+				Value:  fmt.Sprintf("<@%s>\n%s", r.UserID, r.Grid),
+				Inline: true,
+			})
+		}
+
+		if _, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds:          []*discordgo.MessageEmbed{embed},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
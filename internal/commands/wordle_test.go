@@ -0,0 +1,295 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEvaluateGuess(t *testing.T) {
+	tests := []struct {
+		guess, target string
+		want          []int
+	}{
+		{"CRANE", "CRANE", []int{2, 2, 2, 2, 2}},
+		{"SPEED", "ABIDE", []int{0, 0, 1, 0, 1}},
+		{"EERIE", "THERE", []int{1, 0, 1, 0, 2}},
+		{"ROBE", "BORE", []int{1, 2, 1, 2}},
+		{"BANANA", "CABANA", []int{1, 2, 0, 2, 2, 2}},
+		{"LETTERS", "SETTLER", []int{1, 2, 2, 2, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		if got := EvaluateGuess(tt.guess, tt.target); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("EvaluateGuess(%s, %s) = %v, want %v", tt.guess, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestCheckHardMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous []string
+		target   string
+		guess    string
+		wantErr  string
+	}{
+		{"no hints yet", nil, "CRANE", "SLOTH", ""},
+		{"keeps green and yellow", []string{"CRISP"}, "CRANE", "CRAZE", ""},
+		{"moves a green", []string{"CRISP"}, "CRANE", "RACES", "1st letter must be C"},
+		{"drops a yellow", []string{"ALOFT"}, "CRANE", "TRICK", "guess must contain A"},
+		{"yellow in new spot is fine", []string{"ALOFT"}, "CRANE", "NASAL", ""},
+		{"keeps both copies of a letter", []string{"EERIE"}, "THERE", "THREE", ""},
+		{"only one copy reused", []string{"EERIE"}, "THERE", "TRUCE", "guess must contain E"},
+		{"hints from every guess count", []string{"NOTES", "CRISP"}, "CRANE", "CRAMP", "guess must contain N"},
+		{"longer words", []string{"BANANA"}, "CABANA", "CABINS", "4th letter must be A"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckHardMode(tt.guess, tt.previous, tt.target)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWordListPath(t *testing.T) {
+	tests := []struct {
+		lang   string
+		length int
+		want   string
+	}{
+		{"en", 5, "/share/soosa/wordlist_answers.txt"},
+		{"en", 6, "/share/soosa/wordlist_answers_6.txt"},
+		{"es", 5, "/share/soosa/wordlist_answers_es.txt"},
+		{"de", 7, "/share/soosa/wordlist_answers_de_7.txt"},
+	}
+	for _, tt := range tests {
+		if got := wordListPath("/share/soosa/wordlist_answers.txt", tt.lang, tt.length); got != tt.want {
+			t.Errorf("wordListPath(%s, %d) = %s, want %s", tt.lang, tt.length, got, tt.want)
+		}
+	}
+}
+
+func TestValidateWordleAnswers(t *testing.T) {
+	key := wordleListKey{defaultWordleLang, classicWordLength}
+	wordLists[key] = newWordleWordList([]string{"CRANE"}, []string{"SLATE", "QUEST"})
+	defer delete(wordLists, key)
+
+	valid, rejected := validateWordleAnswers([]string{"quest", " Crane ", "QUEST", "", "XYZZY", "SWORDS"})
+	if want := []string{"QUEST", "CRANE"}; !reflect.DeepEqual(valid, want) {
+		t.Errorf("valid = %v, want %v", valid, want)
+	}
+	if want := []string{"XYZZY", "SWORDS"}; !reflect.DeepEqual(rejected, want) {
+		t.Errorf("rejected = %v, want %v", rejected, want)
+	}
+}
+
+func TestDailyWordFrom(t *testing.T) {
+	answers := []string{"ARMOR", "BLADE", "CHESS", "GUILD", "QUEST"}
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	if a, b := dailyWordFrom(answers, day), dailyWordFrom(answers, day.Add(23*time.Hour)); a != b {
+		t.Errorf("same day gave different words: %s, %s", a, b)
+	}
+
+	seen := make(map[string]bool)
+	for d := 0; d < 30; d++ {
+		seen[dailyWordFrom(answers, day.AddDate(0, 0, d))] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("30 days only produced %d distinct words", len(seen))
+	}
+}
+
+func TestNewWordleWordList(t *testing.T) {
+	list := newWordleWordList([]string{"CRANE", "SLATE", "CRANE"}, []string{"QUEST", "SLATE"})
+
+	if want := []string{"CRANE", "SLATE"}; !reflect.DeepEqual(list.Answers, want) {
+		t.Errorf("Answers = %v, want %v", list.Answers, want)
+	}
+	for _, word := range []string{"CRANE", "SLATE", "QUEST"} {
+		if !list.Contains(word) {
+			t.Errorf("Contains(%s) = false, want true", word)
+		}
+	}
+	if list.Contains("XYZZY") {
+		t.Error("Contains(XYZZY) = true, want false")
+	}
+}
+
+func TestReloadWordleWords(t *testing.T) {
+	dir := t.TempDir()
+	answers, allowed := filepath.Join(dir, "answers.txt"), filepath.Join(dir, "allowed.txt")
+	write := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(answers, "crane\n")
+	write(allowed, "slate\n")
+
+	saved := wordLists
+	defer func() { wordLists, wordleAnswersPath, wordleAllowedPath = saved, "", "" }()
+
+	if err := LoadWordleWords(answers, allowed); err != nil {
+		t.Fatal(err)
+	}
+	before := wordleFilesSignature(answers, allowed, "")
+	if IsValidWord("en", "QUEST") {
+		t.Fatal("QUEST valid before it was added")
+	}
+
+	write(allowed, "slate\nquest\n")
+	if wordleFilesSignature(answers, allowed, "") == before {
+		t.Error("signature did not change after editing a file")
+	}
+	if err := ReloadWordleWords(); err != nil {
+		t.Fatal(err)
+	}
+	if !IsValidWord("en", "QUEST") {
+		t.Error("QUEST not valid after reload")
+	}
+
+	// A broken reload keeps the previous lists
+	os.Remove(answers)
+	if err := ReloadWordleWords(); err == nil {
+		t.Error("reload with a missing answers file succeeded")
+	}
+	if !IsValidWord("en", "CRANE") {
+		t.Error("lists were dropped after a failed reload")
+	}
+}
+
+func TestShippedWordleLists(t *testing.T) {
+	saved := wordLists
+	defer func() { wordLists, wordleAnswersPath, wordleAllowedPath = saved, "", "" }()
+
+	if err := LoadWordleWords("../../wordlist_answers.txt", "../../wordlist_allowed.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A few hundred guesses rejects too many real words, so every shipped list needs a full dictionary
+	tests := []struct {
+		lang       string
+		length     int
+		minAllowed int
+	}{
+		{"en", 4, 3000},
+		{"en", 5, 10000},
+		{"en", 6, 5000},
+		{"en", 7, 3000},
+		{"es", 5, 2500},
+		{"de", 5, 2500},
+	}
+	for _, tt := range tests {
+		list := getWordleWordList(tt.lang, tt.length)
+		if list == nil {
+			t.Errorf("%d-letter %s list not loaded", tt.length, tt.lang)
+			continue
+		}
+		if len(list.Allowed) < tt.minAllowed {
+			t.Errorf("%d-letter %s list allows %d words, want at least %d", tt.length, tt.lang, len(list.Allowed), tt.minAllowed)
+		}
+		for _, word := range list.Answers {
+			if n := len([]rune(word)); n != tt.length {
+				t.Errorf("%d-letter %s answer %s has %d letters", tt.length, tt.lang, word, n)
+			}
+		}
+	}
+}
+
+func TestParseWordleArchiveDate(t *testing.T) {
+	now := time.Date(2026, 3, 14, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		date    string
+		wantErr bool
+	}{
+		{"2026-03-13", false},
+		{"2025-03-14", false},
+		{"2026-03-14", true},
+		{"2026-03-15", true},
+		{"2025-03-13", true},
+		{"14/03/2026", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		_, err := parseWordleArchiveDate(tt.date, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseWordleArchiveDate(%q) error = %v, wantErr %v", tt.date, err, tt.wantErr)
+		}
+	}
+}
+
+func TestArchiveWordleReward(t *testing.T) {
+	for attempts, want := range map[int]int{1: 250, 3: 150, 6: 25, 7: 0} {
+		if got := archiveWordleReward(attempts); got != want {
+			t.Errorf("archiveWordleReward(%d) = %d, want %d", attempts, got, want)
+		}
+	}
+}
+
+func TestNormalizeWordleWord(t *testing.T) {
+	tests := []struct {
+		lang, word, want string
+	}{
+		{"en", " crane ", "CRANE"},
+		{"en", "café", "CAFE"},
+		{"es", "árbol", "ARBOL"},
+		{"es", "niño", "NIÑO"},
+		{"es", "pingüino", "PINGUINO"},
+		{"de", "bäume", "BÄUME"},
+		{"de", "café", "CAFE"},
+		{"de", "straße", "STRAßE"},
+	}
+	for _, tt := range tests {
+		if got := normalizeWordleWord(tt.lang, tt.word); got != tt.want {
+			t.Errorf("normalizeWordleWord(%s, %q) = %q, want %q", tt.lang, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateGuessNonASCII(t *testing.T) {
+	tests := []struct {
+		guess, target string
+		want          []int
+	}{
+		{"MAÑAN", "MAÑAN", []int{2, 2, 2, 2, 2}},
+		{"NIÑOS", "SUEÑO", []int{0, 0, 1, 1, 1}},
+		{"KÄSEN", "BÄUME", []int{0, 2, 0, 1, 0}},
+		{"ПРИВЕТ", "ПРОВОД", []int{2, 2, 0, 2, 0, 0}},
+	}
+	for _, tt := range tests {
+		if got := EvaluateGuess(tt.guess, tt.target); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("EvaluateGuess(%s, %s) = %v, want %v", tt.guess, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestWordleModeName(t *testing.T) {
+	tests := []struct {
+		lang   string
+		length int
+		hard   bool
+		want   string
+	}{
+		{"en", 5, false, "classic"},
+		{"", 5, true, "classic-hard"},
+		{"en", 6, false, "6-letter"},
+		{"es", 5, false, "classic-es"},
+		{"de", 5, true, "classic-hard-de"},
+	}
+	for _, tt := range tests {
+		if got := wordleModeName(tt.lang, tt.length, tt.hard); got != tt.want {
+			t.Errorf("wordleModeName(%q, %d, %v) = %s, want %s", tt.lang, tt.length, tt.hard, got, tt.want)
+		}
+	}
+}
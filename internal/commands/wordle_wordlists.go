@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// minWordleListSize keeps a custom list from repeating the same few words every week.
+	minWordleListSize = 10
+	// maxWordleListUpload caps how much of an uploaded file is read.
+	maxWordleListUpload = 256 * 1024
+)
+
+// wordlePacks are the bundled themed answer lists, keyed by file name without the .txt extension.
+var wordlePacks = make(map[string][]string)
+
+// LoadWordlePacks loads every .txt file in dir as a themed answer pack. Words that aren't valid guesses are dropped, so call it after LoadWordleWords.
+func LoadWordlePacks(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return err
+	}
+
+	packs := make(map[string][]string)
+	for _, path := range paths {
+		lines, err := readLines(path)
+		if err != nil {
+			log.Printf("[WORDLE] Failed to load pack %s: %v", path, err)
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimSuffix(filepath.Base(path), ".txt"))
+		words, rejected := validateWordleAnswers(lines)
+		if len(rejected) > 0 {
+			log.Printf("[WORDLE] Pack %s: skipped %d invalid words: %s", name, len(rejected), strings.Join(rejected, ", "))
+		}
+		if len(words) < minWordleListSize {
+			log.Printf("[WORDLE] Pack %s disabled: only %d valid words", name, len(words))
+			continue
+		}
+		packs[name] = words
+	}
+
+	wordListsMutex.Lock()
+	wordlePacks = packs
+	wordlePacksDir = dir
+	wordListsMutex.Unlock()
+	return nil
+}
+
+// getWordlePack returns the words in a loaded pack.
+func getWordlePack(name string) ([]string, bool) {
+	wordListsMutex.RLock()
+	defer wordListsMutex.RUnlock()
+	words, ok := wordlePacks[name]
+	return words, ok
+}
+
+// wordlePackNames returns the loaded pack names in alphabetical order.
+func wordlePackNames() []string {
+	wordListsMutex.RLock()
+	defer wordListsMutex.RUnlock()
+	names := make([]string, 0, len(wordlePacks))
+	for name := range wordlePacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateWordleAnswers uppercases and dedupes words and splits them into classic-length words that are valid guesses and everything else.
+func validateWordleAnswers(lines []string) (valid, rejected []string) {
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		word := strings.ToUpper(strings.TrimSpace(line))
+		if word == "" || seen[word] {
+			continue
+		}
+		seen[word] = true
+
+		if len([]rune(word)) != classicWordLength || !IsValidWord(defaultWordleLang, word) {
+			rejected = append(rejected, word)
+			continue
+		}
+		valid = append(valid, word)
+	}
+	return valid, rejected
+}
+
+// GetGuildDailyWord returns a guild's daily word for t's date. The first time a date is asked for, the word is drawn and recorded, so later games and archive replays of that date get the same word even after the lists change.
+func GetGuildDailyWord(guildID, lang string, t time.Time) string {
+	date := t.UTC().Format("2006-01-02")
+	word, err := DB.GetWordleDailyWord(guildID, date, lang)
+	if err != nil {
+		log.Printf("[WORDLE] Failed to load daily word for guild %s on %s: %v", guildID, date, err)
+	}
+	if word != "" {
+		return word
+	}
+
+	word = drawGuildDailyWord(guildID, lang, t)
+	if word == "" || word == "ERROR" {
+		// Nothing worth remembering when the answers aren't loaded
+		return word
+	}
+	stored, err := DB.RecordWordleDailyWord(guildID, date, lang, word)
+	if err != nil || stored == "" {
+		log.Printf("[WORDLE] Failed to record daily word for guild %s on %s: %v", guildID, date, err)
+		return word
+	}
+	return stored
+}
+
+// drawGuildDailyWord picks the daily word from the custom list a guild had on t's date when it had one and the default answers otherwise. Custom lists are English, so other languages always use their bundled answers.
+func drawGuildDailyWord(guildID, lang string, t time.Time) string {
+	if guildID != "" && lang == defaultWordleLang {
+		list, err := DB.GetWordleWordList(guildID, t.UTC().Format("2006-01-02"))
+		if err != nil {
+			log.Printf("[WORDLE] Failed to load word list for guild %s: %v", guildID, err)
+		} else if len(list.Words) > 0 {
+			return dailyWordFrom(list.Words, t)
+		}
+	}
+	return GetDailyWord(lang, t)
+}
+
+// nextWordleDay is the first UTC day a word list change applies to, so the puzzle never changes while people are playing it.
+func nextWordleDay(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// describeWordleWordList turns a stored source into something readable, e.g. "the **gaming** pack".
+func describeWordleWordList(source string) string {
+	if name, ok := strings.CutPrefix(source, "pack:"); ok {
+		return fmt.Sprintf("the **%s** pack", name)
+	}
+	if source == "upload" {
+		return "an uploaded list"
+	}
+	return "the default list"
+}
+
+func handleWordleWordListCommand(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	var pack, attachmentID string
+	reset := false
+	for _, opt := range options {
+		switch opt.Name {
+		case "pack":
+			pack = strings.ToLower(strings.TrimSpace(opt.StringValue()))
+		case "file":
+			attachmentID, _ = opt.Value.(string)
+		case "reset":
+			reset = opt.BoolValue()
+		}
+	}
+
+	packList := "none"
+	if names := wordlePackNames(); len(names) > 0 {
+		packList = strings.Join(names, ", ")
+	}
+
+	next := nextWordleDay(time.Now())
+	switch {
+	case reset:
+		if err := DB.ClearWordleWordList(i.GuildID, next.Format("2006-01-02")); err != nil {
+			log.Printf("[WORDLE] Failed to clear word list: %v", err)
+			respondError(s, i, "Database error.")
+			return
+		}
+		log.Printf("[WORDLE] Guild %s word list reset by %s", i.GuildID, i.Member.User.ID)
+		respondSuccess(s, i, fmt.Sprintf("The daily word goes back to the default list <t:%d:R>, when the next puzzle starts. Today's word stays the same.", next.Unix()))
+
+	case attachmentID != "":
+		attachment, ok := i.ApplicationCommandData().Resolved.Attachments[attachmentID]
+		if !ok {
+			respondError(s, i, "Couldn't find the uploaded file.")
+			return
+		}
+		lines, err := downloadWordleList(attachment.URL)
+		if err != nil {
+			log.Printf("[WORDLE] Failed to download word list: %v", err)
+			respondError(s, i, "Couldn't read the uploaded file.")
+			return
+		}
+		setWordleWordList(s, i, "upload", lines, next)
+
+	case pack != "":
+		words, ok := getWordlePack(pack)
+		if !ok {
+			respondError(s, i, fmt.Sprintf("Unknown pack '%s'. Available packs: %s", pack, packList))
+			return
+		}
+		setWordleWordList(s, i, "pack:"+pack, words, next)
+
+	default:
+		current, err := DB.GetWordleWordList(i.GuildID, time.Now().UTC().Format("2006-01-02"))
+		if err != nil {
+			log.Printf("[WORDLE] Failed to load word list: %v", err)
+			respondError(s, i, "Database error.")
+			return
+		}
+		upcoming, err := DB.GetWordleWordList(i.GuildID, next.Format("2006-01-02"))
+		if err != nil {
+			log.Printf("[WORDLE] Failed to load word list: %v", err)
+			respondError(s, i, "Database error.")
+			return
+		}
+
+		desc := fmt.Sprintf("This server's daily word comes from %s", describeWordleWordList(current.Source))
+		if len(current.Words) > 0 {
+			desc += fmt.Sprintf(" (%d words)", len(current.Words))
+		}
+		if upcoming.Effective == next.Format("2006-01-02") {
+			desc += fmt.Sprintf(".\nIt switches to %s <t:%d:R>", describeWordleWordList(upcoming.Source), next.Unix())
+		}
+		respondEmbed(s, i, &discordgo.MessageEmbed{
+			Title:       "Wordle Word List",
+			Description: desc + ".\n\nAvailable packs: " + packList,
+			Color:       0x3498db,
+		})
+	}
+}
+
+// setWordleWordList validates the words, schedules them for the guild from the effective day and reports what was rejected.
+func setWordleWordList(s *discordgo.Session, i *discordgo.InteractionCreate, source string, lines []string, effective time.Time) {
+	words, rejected := validateWordleAnswers(lines)
+	if len(words) < minWordleListSize {
+		respondError(s, i, fmt.Sprintf("A word list needs at least %d valid 5-letter words; found %d.", minWordleListSize, len(words)))
+		return
+	}
+
+	if err := DB.SetWordleWordList(i.GuildID, source, words, effective.Format("2006-01-02")); err != nil {
+		log.Printf("[WORDLE] Failed to save word list: %v", err)
+		respondError(s, i, "Database error.")
+		return
+	}
+	log.Printf("[WORDLE] Guild %s word list set to %s (%d words) from %s by %s", i.GuildID, source, len(words), effective.Format("2006-01-02"), i.Member.User.ID)
+
+	msg := fmt.Sprintf("The daily word will come from %s (%d words) <t:%d:R>, when the next puzzle starts. Today's word stays the same.", describeWordleWordList(source), len(words), effective.Unix())
+	if len(rejected) > 0 {
+		shown := rejected
+		if len(shown) > 15 {
+			shown = shown[:15]
+		}
+		msg += fmt.Sprintf("\nSkipped %d words that aren't valid guesses: %s", len(rejected), strings.Join(shown, ", "))
+		if len(rejected) > len(shown) {
+			msg += ", ..."
+		}
+	}
+	respondSuccess(s, i, msg)
+}
+
+// downloadWordleList fetches an uploaded attachment and returns its non-empty lines.
+func downloadWordleList(url string) ([]string, error) {
+	body, err := downloadAttachment(url, maxWordleListUpload)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, line := range strings.FieldsFunc(string(body), func(r rune) bool { return r == '\n' || r == '\r' || r == ',' }) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
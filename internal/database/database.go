@@ -89,7 +89,289 @@ func (d *DB) migrate() error {
 	// Add new columns to existing table if they don't exist
 	_, _ = d.conn.Exec(`ALTER TABLE wordle_state ADD COLUMN message_id TEXT DEFAULT '';`)
 	_, _ = d.conn.Exec(`ALTER TABLE wordle_state ADD COLUMN channel_id TEXT DEFAULT '';`)
+	_, _ = d.conn.Exec(`ALTER TABLE wordle_state ADD COLUMN hard_mode BOOLEAN NOT NULL DEFAULT 0;`)
 
+	_, _ = d.conn.Exec(`ALTER TABLE wordle_state ADD COLUMN started_at INTEGER NOT NULL DEFAULT 0;`)
+	_, _ = d.conn.Exec(`ALTER TABLE wordle_state ADD COLUMN lang TEXT NOT NULL DEFAULT 'en';`)
+
+	if err := d.migrateWordleStatsModes(); err != nil {
+		return err
+	}
+
+	// Daily Wordle results, one row per player per guild per day per language
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS wordle_results (
+		guild_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		date TEXT NOT NULL,
+		lang TEXT NOT NULL DEFAULT 'en',
+		won BOOLEAN NOT NULL DEFAULT 0,
+		attempts INTEGER NOT NULL,
+		solve_seconds INTEGER NOT NULL DEFAULT 0,
+		grid TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (guild_id, user_id, date, lang)
+	);`)
+	if err != nil {
+		return err
+	}
+
+	// Per-guild Wordle settings
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS wordle_settings (
+		guild_id TEXT PRIMARY KEY,
+		results_channel_id TEXT DEFAULT '',
+		last_posted TEXT DEFAULT ''
+	);`)
+	if err != nil {
+		return err
+	}
+
+	// Languages already posted for a day's results, so a retry after a failed post skips them
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS wordle_results_posted (
+		guild_id TEXT NOT NULL,
+		date TEXT NOT NULL,
+		lang TEXT NOT NULL,
+		PRIMARY KEY (guild_id, date, lang)
+	);`)
+	if err != nil {
+		return err
+	}
+
+	// Finished archive puzzles, kept apart from wordle_stats so catching up doesn't touch the daily streak
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS wordle_archive (
+		user_id TEXT NOT NULL,
+		date TEXT NOT NULL,
+		lang TEXT NOT NULL DEFAULT 'en',
+		won BOOLEAN NOT NULL DEFAULT 0,
+		attempts INTEGER NOT NULL,
+		PRIMARY KEY (user_id, date, lang)
+	);`)
+	if err != nil {
+		return err
+	}
+
+	if err := d.migrateWordleLanguages(); err != nil {
+		return err
+	}
+
+	// Trivia questions imported by server admins, on top of the bundled bank
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS trivia_questions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		guild_id TEXT NOT NULL,
+		category TEXT NOT NULL,
+		difficulty TEXT NOT NULL,
+		question TEXT NOT NULL,
+		correct_answer TEXT NOT NULL,
+		incorrect_answers TEXT NOT NULL,
+		UNIQUE (guild_id, question)
+	);`)
+	if err != nil {
+		return err
+	}
+
+	// Win/loss records for the two-player board games
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS board_stats (
+		user_id TEXT NOT NULL,
+		game TEXT NOT NULL,
+		wins INTEGER DEFAULT 0,
+		losses INTEGER DEFAULT 0,
+		draws INTEGER DEFAULT 0,
+		PRIMARY KEY (user_id, game)
+	);`)
+	if err != nil {
+		return err
+	}
+
+	// Per-guild casino payouts; empty columns mean the defaults
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS casino_settings (
+		guild_id TEXT PRIMARY KEY,
+		roulette_payouts TEXT DEFAULT '',
+		roulette_commission INTEGER DEFAULT 0,
+		slots_symbols TEXT DEFAULT '',
+		slots_commission INTEGER DEFAULT 0
+	);`)
+	if err != nil {
+		return err
+	}
+
+	// Per-guild Wordle answer lists, replacing the bundled answers for the daily word from their effective date on
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS wordle_wordlists (
+		guild_id TEXT NOT NULL,
+		effective TEXT NOT NULL DEFAULT '',
+		source TEXT NOT NULL,
+		words TEXT NOT NULL,
+		PRIMARY KEY (guild_id, effective)
+	);`)
+	if err != nil {
+		return err
+	}
+
+	if err := d.migrateWordleWordListDates(); err != nil {
+		return err
+	}
+
+	// The word each guild got for each day, fixed the first time it's drawn so archives replay what was really played
+	_, err = d.conn.Exec(`
+	CREATE TABLE IF NOT EXISTS wordle_daily_words (
+		guild_id TEXT NOT NULL,
+		date TEXT NOT NULL,
+		lang TEXT NOT NULL,
+		word TEXT NOT NULL,
+		PRIMARY KEY (guild_id, date, lang)
+	);`)
+	return err
+}
+
+// migrateWordleWordListDates keys wordle_wordlists by the date each list takes effect, so a change never swaps the word mid-day. Existing lists apply from the beginning.
+func (d *DB) migrateWordleWordListDates() error {
+	var hasEffective int
+	if err := d.conn.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('wordle_wordlists') WHERE name = 'effective'`).Scan(&hasEffective); err != nil {
+		return err
+	}
+	if hasEffective > 0 {
+		return nil
+	}
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE wordle_wordlists_new (
+			guild_id TEXT NOT NULL,
+			effective TEXT NOT NULL DEFAULT '',
+			source TEXT NOT NULL,
+			words TEXT NOT NULL,
+			PRIMARY KEY (guild_id, effective)
+		);`,
+		`INSERT INTO wordle_wordlists_new (guild_id, source, words) SELECT guild_id, source, words FROM wordle_wordlists;`,
+		`DROP TABLE wordle_wordlists;`,
+		`ALTER TABLE wordle_wordlists_new RENAME TO wordle_wordlists;`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to migrate wordle_wordlists: %w", err)
+		}
+	}
+	log.Println("Migrated wordle_wordlists to dated lists.")
+	return tx.Commit()
+}
+
+// migrateWordleStatsModes rebuilds wordle_stats keyed by (user_id, mode) so each variant keeps its own streaks. Existing rows become "classic".
+func (d *DB) migrateWordleStatsModes() error {
+	var hasMode int
+	if err := d.conn.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('wordle_stats') WHERE name = 'mode'`).Scan(&hasMode); err != nil {
+		return err
+	}
+	if hasMode > 0 {
+		return nil
+	}
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE wordle_stats_new (
+			user_id TEXT NOT NULL,
+			mode TEXT NOT NULL DEFAULT 'classic',
+			games_played INTEGER DEFAULT 0,
+			games_won INTEGER DEFAULT 0,
+			current_streak INTEGER DEFAULT 0,
+			max_streak INTEGER DEFAULT 0,
+			distribution TEXT DEFAULT '{}',
+			PRIMARY KEY (user_id, mode)
+		);`,
+		`INSERT INTO wordle_stats_new (user_id, mode, games_played, games_won, current_streak, max_streak, distribution)
+			SELECT user_id, 'classic', games_played, games_won, current_streak, max_streak, distribution FROM wordle_stats;`,
+		`DROP TABLE wordle_stats;`,
+		`ALTER TABLE wordle_stats_new RENAME TO wordle_stats;`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to migrate wordle_stats: %w", err)
+		}
+	}
+	log.Println("Migrated wordle_stats to per-mode stats.")
+	return tx.Commit()
+}
+
+// migrateWordleLanguages adds lang to the wordle_results and wordle_archive keys, so a player can finish each language's daily. Existing rows become "en".
+func (d *DB) migrateWordleLanguages() error {
+	tables := []struct {
+		name    string
+		create  string
+		columns string
+	}{
+		{
+			name: "wordle_results",
+			create: `CREATE TABLE wordle_results_new (
+				guild_id TEXT NOT NULL,
+				user_id TEXT NOT NULL,
+				date TEXT NOT NULL,
+				lang TEXT NOT NULL DEFAULT 'en',
+				won BOOLEAN NOT NULL DEFAULT 0,
+				attempts INTEGER NOT NULL,
+				solve_seconds INTEGER NOT NULL DEFAULT 0,
+				grid TEXT NOT NULL DEFAULT '',
+				PRIMARY KEY (guild_id, user_id, date, lang)
+			);`,
+			columns: "guild_id, user_id, date, won, attempts, solve_seconds, grid",
+		},
+		{
+			name: "wordle_archive",
+			create: `CREATE TABLE wordle_archive_new (
+				user_id TEXT NOT NULL,
+				date TEXT NOT NULL,
+				lang TEXT NOT NULL DEFAULT 'en',
+				won BOOLEAN NOT NULL DEFAULT 0,
+				attempts INTEGER NOT NULL,
+				PRIMARY KEY (user_id, date, lang)
+			);`,
+			columns: "user_id, date, won, attempts",
+		},
+	}
+
+	for _, t := range tables {
+		var hasLang int
+		if err := d.conn.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = 'lang'`, t.name).Scan(&hasLang); err != nil {
+			return err
+		}
+		if hasLang > 0 {
+			continue
+		}
+
+		tx, err := d.conn.Begin()
+		if err != nil {
+			return err
+		}
+		stmts := []string{
+			t.create,
+			fmt.Sprintf(`INSERT INTO %s_new (%s) SELECT %s FROM %s;`, t.name, t.columns, t.columns, t.name),
+			fmt.Sprintf(`DROP TABLE %s;`, t.name),
+			fmt.Sprintf(`ALTER TABLE %s_new RENAME TO %s;`, t.name, t.name),
+		}
+		for _, stmt := range stmts {
+			if _, err := tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to migrate %s: %w", t.name, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		log.Printf("Migrated %s to per-language results.", t.name)
+	}
 	return nil
 }
 
@@ -205,6 +487,7 @@ func (d *DB) Transfer(fromID, toID string, amount int) error {
 
 type WordleStats struct {
 	UserID        string
+	Mode          string // "classic", "classic-hard", "6-letter", "6-letter-hard", ...
 	GamesPlayed   int
 	GamesWon      int
 	CurrentStreak int
@@ -221,12 +504,15 @@ type WordleState struct {
 	Completed  bool
 	MessageID  string
 	ChannelID  string
+	HardMode   bool
+	StartedAt  int64  // Unix seconds, used for solve times
+	Lang       string // "en", "es", "de"
 }
 
-func (d *DB) GetWordleStats(userID string) (*WordleStats, error) {
-	stats := &WordleStats{UserID: userID, Distribution: make(map[int]int)}
+func (d *DB) GetWordleStats(userID, mode string) (*WordleStats, error) {
+	stats := &WordleStats{UserID: userID, Mode: mode, Distribution: make(map[int]int)}
 	var distStr string
-	err := d.conn.QueryRow("SELECT games_played, games_won, current_streak, max_streak, distribution FROM wordle_stats WHERE user_id = ?", userID).Scan(
+	err := d.conn.QueryRow("SELECT games_played, games_won, current_streak, max_streak, distribution FROM wordle_stats WHERE user_id = ? AND mode = ?", userID, mode).Scan(
 		&stats.GamesPlayed, &stats.GamesWon, &stats.CurrentStreak, &stats.MaxStreak, &distStr,
 	)
 	if err == sql.ErrNoRows {
@@ -250,23 +536,23 @@ func (d *DB) UpdateWordleStats(stats *WordleStats) error {
 		return fmt.Errorf("failed to marshal distribution: %w", err)
 	}
 	_, err = d.conn.Exec(`
-		INSERT INTO wordle_stats (user_id, games_played, games_won, current_streak, max_streak, distribution)
-		VALUES (?, ?, ?, ?, ?, ?)
-		ON CONFLICT(user_id) DO UPDATE SET
+		INSERT INTO wordle_stats (user_id, mode, games_played, games_won, current_streak, max_streak, distribution)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id, mode) DO UPDATE SET
 		games_played = excluded.games_played,
 		games_won = excluded.games_won,
 		current_streak = excluded.current_streak,
 		max_streak = excluded.max_streak,
 		distribution = excluded.distribution
-	`, stats.UserID, stats.GamesPlayed, stats.GamesWon, stats.CurrentStreak, stats.MaxStreak, string(distBytes))
+	`, stats.UserID, stats.Mode, stats.GamesPlayed, stats.GamesWon, stats.CurrentStreak, stats.MaxStreak, string(distBytes))
 	return err
 }
 
 func (d *DB) GetWordleState(userID string) (*WordleState, error) {
 	state := &WordleState{UserID: userID}
 	var guessesStr string
-	err := d.conn.QueryRow("SELECT game_type, word, guesses, last_played, completed, message_id, channel_id FROM wordle_state WHERE user_id = ?", userID).Scan(
-		&state.GameType, &state.Word, &guessesStr, &state.LastPlayed, &state.Completed, &state.MessageID, &state.ChannelID,
+	err := d.conn.QueryRow("SELECT game_type, word, guesses, last_played, completed, message_id, channel_id, hard_mode, started_at, lang FROM wordle_state WHERE user_id = ?", userID).Scan(
+		&state.GameType, &state.Word, &guessesStr, &state.LastPlayed, &state.Completed, &state.MessageID, &state.ChannelID, &state.HardMode, &state.StartedAt, &state.Lang,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil // No active state
@@ -286,8 +572,8 @@ func (d *DB) SaveWordleState(state *WordleState) error {
 		return fmt.Errorf("failed to marshal guesses: %w", err)
 	}
 	_, err = d.conn.Exec(`
-		INSERT INTO wordle_state (user_id, game_type, word, guesses, last_played, completed, message_id, channel_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO wordle_state (user_id, game_type, word, guesses, last_played, completed, message_id, channel_id, hard_mode, started_at, lang)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(user_id) DO UPDATE SET
 		game_type = excluded.game_type,
 		word = excluded.word,
@@ -295,7 +581,438 @@ func (d *DB) SaveWordleState(state *WordleState) error {
 		last_played = excluded.last_played,
 		completed = excluded.completed,
 		message_id = excluded.message_id,
-		channel_id = excluded.channel_id
-	`, state.UserID, state.GameType, state.Word, string(guessesBytes), state.LastPlayed, state.Completed, state.MessageID, state.ChannelID)
+		channel_id = excluded.channel_id,
+		hard_mode = excluded.hard_mode,
+		started_at = excluded.started_at,
+		lang = excluded.lang
+	`, state.UserID, state.GameType, state.Word, string(guessesBytes), state.LastPlayed, state.Completed, state.MessageID, state.ChannelID, state.HardMode, state.StartedAt, state.Lang)
+	return err
+}
+
+type WordleResult struct {
+	GuildID      string
+	UserID       string
+	Date         string // YYYY-MM-DD (UTC)
+	Lang         string
+	Won          bool
+	Attempts     int
+	SolveSeconds int
+	Grid         string // Emoji squares only, safe to post publicly
+}
+
+type WordleLeaderboardEntry struct {
+	UserID       string
+	Played       int
+	Won          int
+	AvgAttempts  float64 // Over won games only
+	SolveSeconds int     // Total over won games
+}
+
+// RecordWordleResult stores a finished daily game. A player's first result for a day and language in a guild is kept.
+func (d *DB) RecordWordleResult(r *WordleResult) error {
+	_, err := d.conn.Exec(`
+		INSERT OR IGNORE INTO wordle_results (guild_id, user_id, date, lang, won, attempts, solve_seconds, grid)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, r.GuildID, r.UserID, r.Date, r.Lang, r.Won, r.Attempts, r.SolveSeconds, r.Grid)
+	return err
+}
+
+// HasWordleResult reports whether the user finished the daily for the date and language in any guild.
+func (d *DB) HasWordleResult(userID, date, lang string) (bool, error) {
+	var exists int
+	err := d.conn.QueryRow("SELECT 1 FROM wordle_results WHERE user_id = ? AND date = ? AND lang = ? LIMIT 1", userID, date, lang).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetWordleResults returns a guild's results for one day grouped by language, best first within each: wins, then fewest attempts, then fastest.
+func (d *DB) GetWordleResults(guildID, date string) ([]WordleResult, error) {
+	rows, err := d.conn.Query(`
+		SELECT user_id, lang, won, attempts, solve_seconds, grid FROM wordle_results
+		WHERE guild_id = ? AND date = ?
+		ORDER BY lang ASC, won DESC, attempts ASC, solve_seconds ASC
+	`, guildID, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []WordleResult
+	for rows.Next() {
+		r := WordleResult{GuildID: guildID, Date: date}
+		if err := rows.Scan(&r.UserID, &r.Lang, &r.Won, &r.Attempts, &r.SolveSeconds, &r.Grid); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// GetWordleLeaderboard aggregates a guild's results from the given date onwards ("" for all time), best first.
+func (d *DB) GetWordleLeaderboard(guildID, lang, since string, limit int) ([]WordleLeaderboardEntry, error) {
+	rows, err := d.conn.Query(`
+		SELECT user_id,
+			COUNT(*),
+			SUM(won),
+			COALESCE(AVG(CASE WHEN won THEN attempts END), 0),
+			COALESCE(SUM(CASE WHEN won THEN solve_seconds END), 0)
+		FROM wordle_results
+		WHERE guild_id = ? AND lang = ? AND date >= ?
+		GROUP BY user_id
+		ORDER BY SUM(won) DESC, 4 ASC, 5 ASC
+		LIMIT ?
+	`, guildID, lang, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []WordleLeaderboardEntry
+	for rows.Next() {
+		var e WordleLeaderboardEntry
+		if err := rows.Scan(&e.UserID, &e.Played, &e.Won, &e.AvgAttempts, &e.SolveSeconds); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// SetWordleResultsChannel sets (or clears, with "") the channel that gets the nightly results post.
+func (d *DB) SetWordleResultsChannel(guildID, channelID string) error {
+	_, err := d.conn.Exec(`
+		INSERT INTO wordle_settings (guild_id, results_channel_id) VALUES (?, ?)
+		ON CONFLICT(guild_id) DO UPDATE SET results_channel_id = excluded.results_channel_id
+	`, guildID, channelID)
+	return err
+}
+
+type WordleResultsChannel struct {
+	GuildID    string
+	ChannelID  string
+	LastPosted string
+}
+
+// GetWordleResultsChannels returns every guild with a results channel configured.
+func (d *DB) GetWordleResultsChannels() ([]WordleResultsChannel, error) {
+	rows, err := d.conn.Query("SELECT guild_id, results_channel_id, last_posted FROM wordle_settings WHERE results_channel_id != ''")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var channels []WordleResultsChannel
+	for rows.Next() {
+		var c WordleResultsChannel
+		if err := rows.Scan(&c.GuildID, &c.ChannelID, &c.LastPosted); err != nil {
+			return nil, err
+		}
+		channels = append(channels, c)
+	}
+	return channels, rows.Err()
+}
+
+// MarkWordleResultsPosted records that the results for date were posted, so restarts don't post twice.
+func (d *DB) MarkWordleResultsPosted(guildID, date string) error {
+	_, err := d.conn.Exec("UPDATE wordle_settings SET last_posted = ? WHERE guild_id = ?", date, guildID)
 	return err
 }
+
+// MarkWordleResultsLangPosted records that one language's results for date were posted to the guild.
+func (d *DB) MarkWordleResultsLangPosted(guildID, date, lang string) error {
+	_, err := d.conn.Exec("INSERT OR IGNORE INTO wordle_results_posted (guild_id, date, lang) VALUES (?, ?, ?)", guildID, date, lang)
+	return err
+}
+
+// GetWordleResultsPostedLangs returns the languages whose results for date were already posted to the guild.
+func (d *DB) GetWordleResultsPostedLangs(guildID, date string) (map[string]bool, error) {
+	rows, err := d.conn.Query("SELECT lang FROM wordle_results_posted WHERE guild_id = ? AND date = ?", guildID, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posted := make(map[string]bool)
+	for rows.Next() {
+		var lang string
+		if err := rows.Scan(&lang); err != nil {
+			return nil, err
+		}
+		posted[lang] = true
+	}
+	return posted, rows.Err()
+}
+
+// WordleWordList is a guild's answer list from the UTC date it takes effect. An empty Source means the default list.
+type WordleWordList struct {
+	Source    string
+	Words     []string
+	Effective string // YYYY-MM-DD, or empty for lists set before dates were tracked
+}
+
+// SetWordleWordList schedules a guild's custom answer list to start on the effective date (YYYY-MM-DD). Source is "pack:<name>" or "upload". Setting another list for the same date replaces it.
+func (d *DB) SetWordleWordList(guildID, source string, words []string, effective string) error {
+	if words == nil {
+		words = []string{}
+	}
+	wordsJSON, err := json.Marshal(words)
+	if err != nil {
+		return err
+	}
+	_, err = d.conn.Exec(`
+		INSERT INTO wordle_wordlists (guild_id, effective, source, words) VALUES (?, ?, ?, ?)
+		ON CONFLICT(guild_id, effective) DO UPDATE SET source = excluded.source, words = excluded.words
+	`, guildID, effective, source, string(wordsJSON))
+	return err
+}
+
+// GetWordleWordList returns the answer list a guild uses on date (YYYY-MM-DD). The result has an empty source if the guild is on the default list that day.
+func (d *DB) GetWordleWordList(guildID, date string) (*WordleWordList, error) {
+	list := &WordleWordList{}
+	var wordsJSON string
+	err := d.conn.QueryRow(`
+		SELECT source, words, effective FROM wordle_wordlists
+		WHERE guild_id = ? AND effective <= ? ORDER BY effective DESC LIMIT 1
+	`, guildID, date).Scan(&list.Source, &wordsJSON, &list.Effective)
+	if err == sql.ErrNoRows {
+		return list, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(wordsJSON), &list.Words); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ClearWordleWordList puts a guild back on the default answer list from the effective date (YYYY-MM-DD).
+func (d *DB) ClearWordleWordList(guildID, effective string) error {
+	return d.SetWordleWordList(guildID, "", nil, effective)
+}
+
+// GetWordleDailyWord returns the word recorded as a guild's daily for date and language, or an empty string if none was drawn yet.
+func (d *DB) GetWordleDailyWord(guildID, date, lang string) (string, error) {
+	var word string
+	err := d.conn.QueryRow("SELECT word FROM wordle_daily_words WHERE guild_id = ? AND date = ? AND lang = ?", guildID, date, lang).Scan(&word)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return word, err
+}
+
+// RecordWordleDailyWord stores a guild's daily word for date and language unless one is already stored, and returns whichever word is kept.
+func (d *DB) RecordWordleDailyWord(guildID, date, lang, word string) (string, error) {
+	if _, err := d.conn.Exec(`
+		INSERT OR IGNORE INTO wordle_daily_words (guild_id, date, lang, word) VALUES (?, ?, ?, ?)
+	`, guildID, date, lang, word); err != nil {
+		return "", err
+	}
+	return d.GetWordleDailyWord(guildID, date, lang)
+}
+
+// RecordWordleArchive records a finished archive puzzle. Only the first result for a date and language counts.
+func (d *DB) RecordWordleArchive(userID, date, lang string, won bool, attempts int) error {
+	_, err := d.conn.Exec(`
+		INSERT OR IGNORE INTO wordle_archive (user_id, date, lang, won, attempts) VALUES (?, ?, ?, ?, ?)
+	`, userID, date, lang, won, attempts)
+	return err
+}
+
+// HasWordleArchive reports whether the user already played the archive puzzle for date and language.
+func (d *DB) HasWordleArchive(userID, date, lang string) (bool, error) {
+	var exists int
+	err := d.conn.QueryRow("SELECT 1 FROM wordle_archive WHERE user_id = ? AND date = ? AND lang = ? LIMIT 1", userID, date, lang).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TriviaQuestion is one multiple-choice question. The JSON names match the Open Trivia Database format so its exports can be imported as-is.
+type TriviaQuestion struct {
+	Category   string   `json:"category"`
+	Difficulty string   `json:"difficulty"` // "easy", "medium" or "hard"
+	Question   string   `json:"question"`
+	Correct    string   `json:"correct_answer"`
+	Incorrect  []string `json:"incorrect_answers"`
+}
+
+// AddTriviaQuestions stores imported questions for a guild and returns how many were new. Questions the guild already has are skipped.
+func (d *DB) AddTriviaQuestions(guildID string, questions []TriviaQuestion) (int, error) {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	added := 0
+	for _, q := range questions {
+		incorrectJSON, err := json.Marshal(q.Incorrect)
+		if err != nil {
+			return 0, err
+		}
+		res, err := tx.Exec(`
+			INSERT OR IGNORE INTO trivia_questions (guild_id, category, difficulty, question, correct_answer, incorrect_answers)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, guildID, q.Category, q.Difficulty, q.Question, q.Correct, string(incorrectJSON))
+		if err != nil {
+			return 0, err
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			added++
+		}
+	}
+	return added, tx.Commit()
+}
+
+// GetTriviaQuestions returns the questions a guild has imported.
+func (d *DB) GetTriviaQuestions(guildID string) ([]TriviaQuestion, error) {
+	rows, err := d.conn.Query("SELECT category, difficulty, question, correct_answer, incorrect_answers FROM trivia_questions WHERE guild_id = ?", guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []TriviaQuestion
+	for rows.Next() {
+		var q TriviaQuestion
+		var incorrectJSON string
+		if err := rows.Scan(&q.Category, &q.Difficulty, &q.Question, &q.Correct, &incorrectJSON); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(incorrectJSON), &q.Incorrect); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal answers: %w", err)
+		}
+		questions = append(questions, q)
+	}
+	return questions, rows.Err()
+}
+
+// Casino Methods
+
+// SlotSymbol is one symbol on the slot machine reels. Weight is its relative chance on each reel; Three and Two are the bet multipliers paid for three of a kind and for a pair on the first two reels.
+type SlotSymbol struct {
+	Emoji  string `json:"emoji"`
+	Weight int    `json:"weight"`
+	Three  int    `json:"three"`
+	Two    int    `json:"two"`
+}
+
+// CasinoSettings are a guild's payout overrides. Nil payouts or symbols mean the game's defaults. Commissions are the percentage of winnings the house keeps.
+type CasinoSettings struct {
+	RoulettePayouts    map[string]int // bet group -> payout odds, e.g. "straight" -> 35
+	RouletteCommission int
+	SlotsSymbols       []SlotSymbol
+	SlotsCommission    int
+}
+
+// GetCasinoSettings returns a guild's payout overrides. A guild that never changed anything gets empty settings.
+func (d *DB) GetCasinoSettings(guildID string) (*CasinoSettings, error) {
+	var payoutsJSON, symbolsJSON string
+	settings := &CasinoSettings{}
+	err := d.conn.QueryRow("SELECT roulette_payouts, roulette_commission, slots_symbols, slots_commission FROM casino_settings WHERE guild_id = ?", guildID).Scan(&payoutsJSON, &settings.RouletteCommission, &symbolsJSON, &settings.SlotsCommission)
+	if err == sql.ErrNoRows {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if payoutsJSON != "" {
+		if err := json.Unmarshal([]byte(payoutsJSON), &settings.RoulettePayouts); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal roulette payouts: %w", err)
+		}
+	}
+	if symbolsJSON != "" {
+		if err := json.Unmarshal([]byte(symbolsJSON), &settings.SlotsSymbols); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal slot symbols: %w", err)
+		}
+	}
+	return settings, nil
+}
+
+// SaveCasinoSettings stores a guild's payout overrides.
+func (d *DB) SaveCasinoSettings(guildID string, settings *CasinoSettings) error {
+	var payoutsJSON, symbolsJSON string
+	if settings.RoulettePayouts != nil {
+		raw, err := json.Marshal(settings.RoulettePayouts)
+		if err != nil {
+			return err
+		}
+		payoutsJSON = string(raw)
+	}
+	if settings.SlotsSymbols != nil {
+		raw, err := json.Marshal(settings.SlotsSymbols)
+		if err != nil {
+			return err
+		}
+		symbolsJSON = string(raw)
+	}
+
+	_, err := d.conn.Exec(`
+		INSERT INTO casino_settings (guild_id, roulette_payouts, roulette_commission, slots_symbols, slots_commission) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(guild_id) DO UPDATE SET
+			roulette_payouts = excluded.roulette_payouts,
+			roulette_commission = excluded.roulette_commission,
+			slots_symbols = excluded.slots_symbols,
+			slots_commission = excluded.slots_commission
+	`, guildID, payoutsJSON, settings.RouletteCommission, symbolsJSON, settings.SlotsCommission)
+	return err
+}
+
+// Board Game Methods
+
+type BoardStats struct {
+	UserID string
+	Game   string // "connect4", "tictactoe"
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+// RecordBoardResult adds a finished game to both players' records. For a draw, winnerID and loserID are just the two players.
+func (d *DB) RecordBoardResult(game, winnerID, loserID string, draw bool) error {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	winCol, loseCol := "wins", "losses"
+	if draw {
+		winCol, loseCol = "draws", "draws"
+	}
+	for _, r := range []struct{ userID, column string }{{winnerID, winCol}, {loserID, loseCol}} {
+		_, err := tx.Exec(fmt.Sprintf(`
+			INSERT INTO board_stats (user_id, game, %[1]s) VALUES (?, ?, 1)
+			ON CONFLICT(user_id, game) DO UPDATE SET %[1]s = %[1]s + 1
+		`, r.column), r.userID, game)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetBoardStats returns a user's record for a board game, all zeros if they never played.
+func (d *DB) GetBoardStats(userID, game string) (*BoardStats, error) {
+	stats := &BoardStats{UserID: userID, Game: game}
+	err := d.conn.QueryRow("SELECT wins, losses, draws FROM board_stats WHERE user_id = ? AND game = ?", userID, game).Scan(&stats.Wins, &stats.Losses, &stats.Draws)
+	if err == sql.ErrNoRows {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
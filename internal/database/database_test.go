@@ -0,0 +1,141 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+	d, err := New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
+func TestWordleWordListEffectiveDates(t *testing.T) {
+	d := newTestDB(t)
+
+	if err := d.SetWordleWordList("g", "pack:animals", []string{"HORSE", "CAMEL"}, "2026-03-02"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ClearWordleWordList("g", "2026-03-05"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		date   string
+		source string
+		words  []string
+	}{
+		{"2026-03-01", "", nil},
+		{"2026-03-02", "pack:animals", []string{"HORSE", "CAMEL"}},
+		{"2026-03-04", "pack:animals", []string{"HORSE", "CAMEL"}},
+		{"2026-03-05", "", []string{}},
+		{"2026-04-01", "", []string{}},
+	}
+	for _, tt := range tests {
+		list, err := d.GetWordleWordList("g", tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if list.Source != tt.source || !reflect.DeepEqual(list.Words, tt.words) {
+			t.Errorf("list on %s = %q %v, want %q %v", tt.date, list.Source, list.Words, tt.source, tt.words)
+		}
+	}
+
+	// A second change for the same day replaces the first
+	if err := d.SetWordleWordList("g", "upload", []string{"CRANE"}, "2026-03-05"); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := d.GetWordleWordList("g", "2026-03-06"); list.Source != "upload" {
+		t.Errorf("list after replacing = %q, want upload", list.Source)
+	}
+	if list, _ := d.GetWordleWordList("other", "2026-03-06"); list.Source != "" || list.Words != nil {
+		t.Errorf("unrelated guild got %+v", list)
+	}
+}
+
+func TestMigrateWordleWordListDates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE wordle_wordlists (guild_id TEXT PRIMARY KEY, source TEXT NOT NULL, words TEXT NOT NULL);`,
+		`INSERT INTO wordle_wordlists VALUES ('g', 'upload', '["CRANE"]');`,
+	} {
+		if _, err := old.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old.Close()
+
+	d, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	// Lists from before the migration apply to every date
+	list, err := d.GetWordleWordList("g", "2020-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Source != "upload" || !reflect.DeepEqual(list.Words, []string{"CRANE"}) || list.Effective != "" {
+		t.Errorf("migrated list = %+v", list)
+	}
+}
+
+func TestRecordWordleDailyWord(t *testing.T) {
+	d := newTestDB(t)
+
+	if word, err := d.GetWordleDailyWord("g", "2026-03-02", "en"); err != nil || word != "" {
+		t.Fatalf("unrecorded day = %q, %v", word, err)
+	}
+	if word, err := d.RecordWordleDailyWord("g", "2026-03-02", "en", "CRANE"); err != nil || word != "CRANE" {
+		t.Fatalf("first record = %q, %v", word, err)
+	}
+
+	// Later draws for the same day keep the first word
+	if word, _ := d.RecordWordleDailyWord("g", "2026-03-02", "en", "SLATE"); word != "CRANE" {
+		t.Errorf("second record kept %q, want CRANE", word)
+	}
+	if word, _ := d.RecordWordleDailyWord("g", "2026-03-02", "es", "PERRO"); word != "PERRO" {
+		t.Errorf("other language = %q, want PERRO", word)
+	}
+	if word, _ := d.GetWordleDailyWord("other", "2026-03-02", "en"); word != "" {
+		t.Errorf("other guild = %q, want nothing", word)
+	}
+}
+
+func TestWordleResultsPostedLangs(t *testing.T) {
+	d := newTestDB(t)
+
+	if err := d.MarkWordleResultsLangPosted("g", "2026-03-02", "en"); err != nil {
+		t.Fatal(err)
+	}
+	// Marking twice is harmless
+	if err := d.MarkWordleResultsLangPosted("g", "2026-03-02", "en"); err != nil {
+		t.Fatal(err)
+	}
+
+	posted, err := d.GetWordleResultsPostedLangs("g", "2026-03-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"en": true}; !reflect.DeepEqual(posted, want) {
+		t.Errorf("posted = %v, want %v", posted, want)
+	}
+	if posted, _ := d.GetWordleResultsPostedLangs("g", "2026-03-03"); len(posted) != 0 {
+		t.Errorf("other day = %v, want nothing", posted)
+	}
+	if posted, _ := d.GetWordleResultsPostedLangs("other", "2026-03-02"); len(posted) != 0 {
+		t.Errorf("other guild = %v, want nothing", posted)
+	}
+}
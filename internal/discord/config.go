@@ -21,7 +21,15 @@ type Config struct {
 	CompressionLevel  int
 	WordleAnswersPath string
 	WordleAllowedPath string
-	Database          string
+	WordlePacksDir    string
+	// MusicDir is where /play file looks for local audio; empty disables it
+	MusicDir string
+	// TriviaQuestionsPath is the bundled trivia question bank
+	TriviaQuestionsPath string
+	Database            string
+	// PokerSpectatorDelay is in seconds; 0 disables the delayed hole-card view
+	PokerSpectatorDelay int
+	ProvablyFair        bool
 }
 
 func Load() (*Config, error) {
@@ -68,19 +76,31 @@ func Load() (*Config, error) {
 		}
 	}
 
+	spectatorDelay := 60
+	if delayStr := os.Getenv("POKER_SPECTATOR_DELAY"); delayStr != "" {
+		if idx, err := strconv.Atoi(delayStr); err == nil && idx >= 0 {
+			spectatorDelay = idx
+		}
+	}
+
 	return &Config{
-		DiscordToken:      token,
-		GuildID:           os.Getenv("GUILD_ID"),
-		LogChannelID:      os.Getenv("LOG_CHANNEL_ID"),
-		SubsonicURL:       subURL,
-		SubsonicUser:      subUser,
-		SubsonicPassword:  subPass,
-		FFmpegBitrate:     bitrate,
-		DefaultVolume:     vol,
-		CompressionLevel:  compressionLevel,
-		WordleAnswersPath: getEnv("WORDLE_ANSWERS_PATH", "wordlist_answers.txt"),
-		WordleAllowedPath: getEnv("WORDLE_ALLOWED_PATH", "wordlist_allowed.txt"),
-		Database:          getEnv("DATABASE", "permissions.db"),
+		DiscordToken:        token,
+		GuildID:             os.Getenv("GUILD_ID"),
+		LogChannelID:        os.Getenv("LOG_CHANNEL_ID"),
+		SubsonicURL:         subURL,
+		SubsonicUser:        subUser,
+		SubsonicPassword:    subPass,
+		FFmpegBitrate:       bitrate,
+		DefaultVolume:       vol,
+		CompressionLevel:    compressionLevel,
+		WordleAnswersPath:   getEnv("WORDLE_ANSWERS_PATH", "wordlist_answers.txt"),
+		WordleAllowedPath:   getEnv("WORDLE_ALLOWED_PATH", "wordlist_allowed.txt"),
+		WordlePacksDir:      getEnv("WORDLE_PACKS_DIR", "wordle_packs"),
+		MusicDir:            os.Getenv("MUSIC_DIR"),
+		TriviaQuestionsPath: getEnv("TRIVIA_QUESTIONS_PATH", "trivia_questions.json"),
+		Database:            getEnv("DATABASE", "permissions.db"),
+		PokerSpectatorDelay: spectatorDelay,
+		ProvablyFair:        getEnv("PROVABLY_FAIR", "false") == "true",
 	}, nil
 }
 
@@ -0,0 +1,82 @@
+package player
+
+import (
+	"log"
+
+	"soosa/internal/subsonic"
+)
+
+const (
+	autoplayAhead      = 3  // Songs autoplay keeps queued ahead of the current one
+	autoplayCandidates = 25 // Songs requested from the server each time it tops up
+)
+
+// autoplayFill tops the queue up to autoplayAhead songs. It seeds from the last song played, trying similar songs first, then the artist's top songs, then random songs, and skips anything played recently or already queued.
+func (p *Player) autoplayFill() {
+	p.mu.Lock()
+	need := autoplayAhead - len(p.Queue)
+	seed := p.NowPlaying
+	if len(p.History) > 0 {
+		seed = p.History[len(p.History)-1]
+	}
+	recent := make(map[string]bool)
+	for _, song := range p.History {
+		recent[song.ID] = true
+	}
+	for _, song := range p.Queue {
+		recent[song.ID] = true
+	}
+	if p.NowPlaying != nil {
+		recent[p.NowPlaying.ID] = true
+	}
+	p.mu.Unlock()
+
+	if need <= 0 {
+		return
+	}
+
+	var picked []*subsonic.Song
+	if seed != nil && seed.ArtistID != "" {
+		similar, err := p.subClient.GetSimilarSongs2(seed.ArtistID, autoplayCandidates)
+		if err != nil {
+			log.Printf("[PLAYER] Autoplay: similar songs failed: %v", err)
+		}
+		picked = append(picked, pickAutoplaySongs(similar, recent, need-len(picked))...)
+	}
+	if len(picked) < need && seed != nil && seed.Artist != "" {
+		top, err := p.subClient.GetTopSongs(seed.Artist, autoplayCandidates)
+		if err != nil {
+			log.Printf("[PLAYER] Autoplay: top songs failed: %v", err)
+		}
+		picked = append(picked, pickAutoplaySongs(top, recent, need-len(picked))...)
+	}
+	if len(picked) < need {
+		random, err := p.subClient.GetRandomSongs(autoplayCandidates)
+		if err != nil {
+			log.Printf("[PLAYER] Autoplay: random songs failed: %v", err)
+		}
+		picked = append(picked, pickAutoplaySongs(random, recent, need-len(picked))...)
+	}
+
+	if len(picked) > 0 {
+		log.Printf("[PLAYER] Autoplay added %d songs", len(picked))
+		p.EnqueueAll(picked)
+	}
+}
+
+// pickAutoplaySongs returns up to n candidates that aren't in recent, marking each one it picks as recent so later sources don't repeat it.
+func pickAutoplaySongs(candidates []subsonic.Song, recent map[string]bool, n int) []*subsonic.Song {
+	var picked []*subsonic.Song
+	for idx := range candidates {
+		if len(picked) >= n {
+			break
+		}
+		song := &candidates[idx]
+		if song.ID == "" || recent[song.ID] {
+			continue
+		}
+		recent[song.ID] = true
+		picked = append(picked, song)
+	}
+	return picked
+}
@@ -183,6 +183,35 @@ func EncodeFile(path string, options *EncodeOptions) (session *EncodeSession, er
 	return
 }
 
+// isURLInput reports whether ffmpeg will fetch inFile over the network rather than read a file or pipe.
+func isURLInput(inFile string) bool {
+	return strings.HasPrefix(inFile, "http://") || strings.HasPrefix(inFile, "https://")
+}
+
+// protocolArgs limits what ffmpeg and ffprobe may open for a link to plain web requests, so a playlist or redirect can't send them to files or other protocols. Piped input may open nothing else at all, since it's how links from users arrive and an HLS playlist among them would otherwise have ffmpeg fetch whatever it lists.
+func protocolArgs(inFile string) []string {
+	switch {
+	case isURLInput(inFile):
+		return []string{"-protocol_whitelist", "http,https,tcp,tls"}
+	case inFile == "pipe:0" || inFile == "-":
+		return []string{"-protocol_whitelist", "pipe"}
+	}
+	return nil
+}
+
+// reconnectArgs returns the input flags that let ffmpeg ride out dropped connections. Only the http protocol understands them, so files and pipes get none.
+func reconnectArgs(inFile string) []string {
+	if !isURLInput(inFile) {
+		return nil
+	}
+	return []string{
+		"-reconnect", "1",
+		"-reconnect_at_eof", "1",
+		"-reconnect_streamed", "1",
+		"-reconnect_delay_max", "2",
+	}
+}
+
 func (e *EncodeSession) run() {
 	// Reset running state
 	defer func() {
@@ -213,8 +242,6 @@ func (e *EncodeSession) run() {
 		"-stats",
 	}
 
-	// If reading from a pipe (stdin), we cannot "reconnect". Reconnect flags often cause ffmpeg to buffer extensively until it feels safe. We only use them if we are NOT using a pipe (i.e. we have a file path / URL).
-
 	isPipe := e.pipeReader != nil || inFile == "pipe:0" || inFile == "-"
 
 	if isPipe {
@@ -233,17 +260,10 @@ func (e *EncodeSession) run() {
 	}
 
 	// Add input
+	args = append(args, protocolArgs(inFile)...)
+	args = append(args, reconnectArgs(inFile)...)
 	args = append(args, "-i", inFile)
 
-	if !isPipe {
-		args = append(args,
-			"-reconnect", "1",
-			"-reconnect_at_eof", "1",
-			"-reconnect_streamed", "1",
-			"-reconnect_delay_max", "2",
-		)
-	}
-
 	args = append(args,
 		"-map", "0:a",
 		"-acodec", "libopus",
@@ -268,7 +288,8 @@ func (e *EncodeSession) run() {
 			"-stats",
 		}
 
-		if isPipe {
+		// Compressed input on a pipe (like a radio stream) needs probing to find its format, so only skip it for raw PCM
+		if isPipe && e.options.RawInput {
 			args = append(args,
 				"-analyzeduration", "0",
 				"-probesize", "32",
@@ -277,17 +298,10 @@ func (e *EncodeSession) run() {
 			)
 		}
 
+		args = append(args, protocolArgs(inFile)...)
+		args = append(args, reconnectArgs(inFile)...)
 		args = append(args, "-i", inFile)
 
-		if !isPipe {
-			args = append(args,
-				"-reconnect", "1",
-				"-reconnect_at_eof", "1",
-				"-reconnect_streamed", "1",
-				"-reconnect_delay_max", "2",
-			)
-		}
-
 		args = append(args,
 			"-map", "0:a",
 			"-acodec", "pcm_s16le",
@@ -397,7 +411,8 @@ func (e *EncodeSession) writeMetadataFrame() {
 	var cmdBuf bytes.Buffer
 	// get ffprobe data
 	if e.pipeReader == nil {
-		ffprobe := exec.Command("ffprobe", "-v", "quiet", "-print_format", "json", "-show_format", e.filePath)
+		probeArgs := append([]string{"-v", "quiet", "-print_format", "json", "-show_format"}, protocolArgs(e.filePath)...)
+		ffprobe := exec.Command("ffprobe", append(probeArgs, e.filePath)...)
 		ffprobe.Stdout = &cmdBuf
 
 		err := ffprobe.Start()
@@ -15,6 +15,7 @@ type Manager struct {
 	players   map[string]*Player
 	subClient *subsonic.Client
 	session   *discordgo.Session
+	onEvent   EventHandler
 	Config    *discord.Config
 	mu        sync.Mutex
 }
@@ -46,6 +47,7 @@ func (m *Manager) GetOrCreatePlayer(guildID string) *Player {
 	}
 
 	p := NewPlayer(m.session, m.subClient, guildID, m.Config)
+	p.onEvent = m.onEvent
 	m.players[guildID] = p
 	log.Printf("[PLAYER] Created new player for Guild %s", guildID)
 	return p
@@ -65,6 +67,13 @@ func (m *Manager) Remove(guildID string) {
 	}
 }
 
+// SetEventHandler sets the handler notified of events from players created after this call.
+func (m *Manager) SetEventHandler(h EventHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEvent = h
+}
+
 // GetSubsonicClient returns the Subsonic client.
 func (m *Manager) GetSubsonicClient() *subsonic.Client {
 	return m.subClient
@@ -0,0 +1,117 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress is returned for links that lead to the bot's own machine or a private network.
+var ErrBlockedAddress = errors.New("links to local or private addresses can't be played")
+
+const (
+	dialTimeout    = 15 * time.Second
+	resolveTimeout = 10 * time.Second
+)
+
+// blockedPrefixes are ranges that aren't reachable on the public internet but that net/netip has no predicate for.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can reach private IPv4 addresses
+}
+
+// blockedIP reports whether ip is loopback, private, link-local or otherwise somewhere a link from a user shouldn't reach.
+func blockedIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// guardDial returns a check that runs before every outgoing connection, after the host has been resolved, so redirects and DNS changes can't reach an address blocked reports either.
+func guardDial(blocked func(netip.Addr) bool) func(network, address string, _ syscall.RawConn) error {
+	return func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		ip, err := netip.ParseAddr(host)
+		if err != nil || blocked(ip) {
+			return ErrBlockedAddress
+		}
+		return nil
+	}
+}
+
+// newSourceClient returns a client that refuses to connect to addresses blocked reports and, like the station client, understands SHOUTcast v1 servers. Environment proxies are ignored, since the proxy would make the connection instead.
+func newSourceClient(blocked func(netip.Addr) bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = icyDial((&net.Dialer{Timeout: dialTimeout, Control: guardDial(blocked)}).DialContext)
+	return &http.Client{Transport: transport}
+}
+
+// CheckURL makes sure link is an http or https URL whose host resolves only to public addresses.
+func CheckURL(link string) error {
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrInvalidURL
+	}
+
+	host := parsed.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if blockedIP(ip) {
+			return ErrBlockedAddress
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("couldn't resolve %s: %w", host, err)
+	}
+	for _, ip := range ips {
+		if blockedIP(ip) {
+			return ErrBlockedAddress
+		}
+	}
+	return nil
+}
+
+// openURL fetches a link a user gave with the guarded client. The body is all ffmpeg and ffprobe ever see of it, since if they were handed the link they would look up the host and follow redirects and playlists on their own.
+func openURL(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, ErrInvalidURL
+	}
+	resp, err := sourceHTTP.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		return nil, fmt.Errorf("couldn't reach that link: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("that link returned %s", resp.Status)
+	}
+	return resp, nil
+}
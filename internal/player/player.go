@@ -1,9 +1,11 @@
 package player
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -13,32 +15,103 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
+// ErrQueuePosition is returned when a queue position is out of range.
+var ErrQueuePosition = errors.New("no song at that queue position")
+
+// ErrNotPlaying is returned when an action needs a song to be playing.
+var ErrNotPlaying = errors.New("nothing is playing")
+
+// ErrNotSeekable is returned when seeking in a live stream.
+var ErrNotSeekable = errors.New("live streams can't be seeked")
+
+// LoopMode controls what the player does when a song ends.
+type LoopMode int
+
+const (
+	LoopOff      LoopMode = iota // Play through the queue once
+	LoopTrack                    // Replay the current song until skipped
+	LoopQueue                    // Put finished songs back on the end of the queue
+	LoopAutoplay                 // Keep the queue topped up with songs like the ones played
+)
+
+var loopModes = []LoopMode{LoopOff, LoopTrack, LoopQueue, LoopAutoplay}
+
+// String returns the name used for the mode in commands and embeds.
+func (m LoopMode) String() string {
+	switch m {
+	case LoopTrack:
+		return "track"
+	case LoopQueue:
+		return "queue"
+	case LoopAutoplay:
+		return "autoplay"
+	default:
+		return "off"
+	}
+}
+
+// ParseLoopMode converts a name from String back into a LoopMode.
+func ParseLoopMode(name string) (LoopMode, bool) {
+	for _, m := range loopModes {
+		if m.String() == name {
+			return m, true
+		}
+	}
+	return LoopOff, false
+}
+
+// Event tells an EventHandler what changed on a player.
+type Event int
+
+const (
+	EventTrackChange Event = iota // A new song started playing
+	EventProgress                 // Periodic tick while a song is playing, for progress displays
+)
+
+// ProgressInterval is how often EventProgress fires while a song plays.
+const ProgressInterval = 15 * time.Second
+
+// EventHandler is notified of player events. It runs off the playback goroutine, but a player's events reach it one at a time and in the order they happened.
+type EventHandler func(s *discordgo.Session, p *Player, event Event)
+
 // Player manages audio playback for a single guild.
 type Player struct {
 	GuildID    string
 	VoiceConn  *discordgo.VoiceConnection
 	Queue      []*subsonic.Song
 	NowPlaying *subsonic.Song
-	IsPlaying  bool
-	IsPaused   bool
-	Volume     int // 0-256, default 256
+	// StreamTitle is the title a live stream last announced for what it's playing
+	StreamTitle string
+	IsPlaying   bool
+	IsPaused    bool
+	Volume      int // 0-256, default 256
+	Loop        LoopMode
 
 	History       []*subsonic.Song
 	LastMsgID     string
 	LastChannelID string
 
+	requeued bool // PlayPrevious already put the current song back in the queue
+
 	stopChan         chan struct{}
 	skipChan         chan struct{}
 	volumeChangeChan chan struct{} // Signal to update PCM volume (does not restart stream)
+	seekChan         chan int      // Seconds to restart the current song from
 	done             chan struct{}
 
-	encoding *EncodeSession
-	stream   *StreamingSession
+	encoding    *EncodeSession
+	stream      *StreamingSession
+	startOffset int // Seconds into the song the current stream started at
 
 	session   *discordgo.Session
 	subClient *subsonic.Client
+	onEvent   EventHandler
 	Config    *discord.Config
 	mu        sync.Mutex
+
+	eventMu    sync.Mutex
+	events     []Event // Waiting to be handled, oldest first
+	delivering bool    // A goroutine is working through events
 }
 
 // NewPlayer creates a new Player for a guild.
@@ -49,6 +122,7 @@ func NewPlayer(session *discordgo.Session, subClient *subsonic.Client, guildID s
 		stopChan:         make(chan struct{}),
 		skipChan:         make(chan struct{}),
 		volumeChangeChan: make(chan struct{}, 1), // Buffered to avoid blocking
+		seekChan:         make(chan int, 1),
 		done:             make(chan struct{}),
 		session:          session,
 		subClient:        subClient,
@@ -64,6 +138,48 @@ func (p *Player) SetLastMessage(msgID, channelID string) {
 	p.LastChannelID = channelID
 }
 
+// LastMessage returns the message and channel IDs of the Now Playing message, if there is one.
+func (p *Player) LastMessage() (string, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.LastMsgID, p.LastChannelID
+}
+
+// emit notifies the event handler, if one is set, without holding up playback. Events are handled one at a time, so an update rendered for an old song can't land after one for the new song. An event just like the last one still waiting is dropped, since the handler reads the player's state when it runs anyway.
+func (p *Player) emit(event Event) {
+	if p.onEvent == nil {
+		return
+	}
+
+	p.eventMu.Lock()
+	defer p.eventMu.Unlock()
+	if n := len(p.events); n > 0 && p.events[n-1] == event {
+		return
+	}
+	p.events = append(p.events, event)
+	if !p.delivering {
+		p.delivering = true
+		go p.deliverEvents()
+	}
+}
+
+// deliverEvents hands waiting events to the handler in order, stopping once none are left.
+func (p *Player) deliverEvents() {
+	for {
+		p.eventMu.Lock()
+		if len(p.events) == 0 {
+			p.delivering = false
+			p.eventMu.Unlock()
+			return
+		}
+		event := p.events[0]
+		p.events = p.events[1:]
+		p.eventMu.Unlock()
+
+		p.onEvent(p.session, p, event)
+	}
+}
+
 // Enqueue adds a song to the queue.
 func (p *Player) Enqueue(song *subsonic.Song) {
 	p.mu.Lock()
@@ -72,6 +188,14 @@ func (p *Player) Enqueue(song *subsonic.Song) {
 	log.Printf("[PLAYER] Enqueued: %s - %s", song.Artist, song.Title)
 }
 
+// EnqueueAll adds several songs to the end of the queue, keeping their order.
+func (p *Player) EnqueueAll(songs []*subsonic.Song) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Queue = append(p.Queue, songs...)
+	log.Printf("[PLAYER] Enqueued %d songs", len(songs))
+}
+
 // GetQueue returns a copy of the current queue.
 func (p *Player) GetQueue() []*subsonic.Song {
 	p.mu.Lock()
@@ -81,6 +205,90 @@ func (p *Player) GetQueue() []*subsonic.Song {
 	return q
 }
 
+// RemoveAt removes the song at a zero-based queue index and returns it.
+func (p *Player) RemoveAt(index int) (*subsonic.Song, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if index < 0 || index >= len(p.Queue) {
+		return nil, ErrQueuePosition
+	}
+	song := p.Queue[index]
+	p.Queue = append(p.Queue[:index], p.Queue[index+1:]...)
+	log.Printf("[PLAYER] Removed from queue: %s - %s", song.Artist, song.Title)
+	return song, nil
+}
+
+// Move moves the song at zero-based index from to index to, shifting the songs in between.
+func (p *Player) Move(from, to int) (*subsonic.Song, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if from < 0 || from >= len(p.Queue) || to < 0 || to >= len(p.Queue) {
+		return nil, ErrQueuePosition
+	}
+	song := p.Queue[from]
+	p.Queue = append(p.Queue[:from], p.Queue[from+1:]...)
+	p.Queue = append(p.Queue[:to], append([]*subsonic.Song{song}, p.Queue[to:]...)...)
+	return song, nil
+}
+
+// Shuffle randomises the order of the upcoming songs and returns how many were shuffled.
+func (p *Player) Shuffle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rand.Shuffle(len(p.Queue), func(a, b int) {
+		p.Queue[a], p.Queue[b] = p.Queue[b], p.Queue[a]
+	})
+	return len(p.Queue)
+}
+
+// ClearQueue drops every upcoming song, leaving the current one playing, and returns how many were removed.
+func (p *Player) ClearQueue() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := len(p.Queue)
+	p.Queue = nil
+	return n
+}
+
+// JumpTo drops the songs ahead of a zero-based queue index and skips to it.
+func (p *Player) JumpTo(index int) (*subsonic.Song, error) {
+	p.mu.Lock()
+	if index < 0 || index >= len(p.Queue) {
+		p.mu.Unlock()
+		return nil, ErrQueuePosition
+	}
+	song := p.Queue[index]
+	p.Queue = p.Queue[index:]
+	p.mu.Unlock()
+
+	p.Skip()
+	return song, nil
+}
+
+// SetLoop changes the loop mode.
+func (p *Player) SetLoop(mode LoopMode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Loop = mode
+	log.Printf("[PLAYER] Loop mode set to %s", mode)
+}
+
+// GetLoop returns the current loop mode.
+func (p *Player) GetLoop() LoopMode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.Loop
+}
+
+// CycleLoop switches to the next loop mode (off, track, queue, autoplay) and returns it.
+func (p *Player) CycleLoop() LoopMode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Loop = (p.Loop + 1) % LoopMode(len(loopModes))
+	log.Printf("[PLAYER] Loop mode set to %s", p.Loop)
+	return p.Loop
+}
+
 // dequeue removes and returns the next song, or nil if empty.
 func (p *Player) dequeue() *subsonic.Song {
 	p.mu.Lock()
@@ -185,6 +393,7 @@ func (p *Player) PlayPrevious() {
 	// Push current NowPlaying to front of Queue (if exists) so we don't lose it
 	if p.NowPlaying != nil {
 		p.Queue = append([]*subsonic.Song{p.NowPlaying}, p.Queue...)
+		p.requeued = true
 	}
 	// Push prevSong to the very front
 	p.Queue = append([]*subsonic.Song{prevSong}, p.Queue...)
@@ -244,19 +453,142 @@ func (p *Player) SetVolume(vol int) {
 	}
 }
 
+// Seek restarts the current song from the given position, clamped to the song's length.
+func (p *Player) Seek(pos time.Duration) error {
+	p.mu.Lock()
+	song := p.NowPlaying
+	p.mu.Unlock()
+	if song == nil {
+		return ErrNotPlaying
+	}
+	if song.IsLive() {
+		return ErrNotSeekable
+	}
+
+	seconds := int(pos / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+	if song.Duration > 0 && seconds >= song.Duration {
+		seconds = song.Duration - 1
+	}
+
+	// Replace any seek that hasn't been picked up yet
+	select {
+	case <-p.seekChan:
+	default:
+	}
+	select {
+	case p.seekChan <- seconds:
+	default:
+	}
+	log.Printf("[PLAYER] Seeking to %s", FormatDuration(seconds))
+	return nil
+}
+
+// Position returns how far into the current song playback is.
+func (p *Player) Position() time.Duration {
+	p.mu.Lock()
+	stream := p.stream
+	pos := time.Duration(p.startOffset) * time.Second
+	p.mu.Unlock()
+	if stream != nil {
+		pos += stream.PlaybackPosition()
+	}
+	return pos
+}
+
+// State is a consistent copy of what a player is doing, so it can be rendered without holding the player's lock.
+type State struct {
+	NowPlaying  *subsonic.Song
+	IsPlaying   bool
+	IsPaused    bool
+	Volume      int
+	Loop        LoopMode
+	Queue       []*subsonic.Song
+	StreamTitle string
+	Position    time.Duration
+}
+
+// Snapshot returns the player's current state, read under its lock.
+func (p *Player) Snapshot() State {
+	p.mu.Lock()
+	st := State{
+		NowPlaying:  p.NowPlaying,
+		IsPlaying:   p.IsPlaying,
+		IsPaused:    p.IsPaused,
+		Volume:      p.Volume,
+		Loop:        p.Loop,
+		Queue:       make([]*subsonic.Song, len(p.Queue)),
+		StreamTitle: p.StreamTitle,
+		Position:    time.Duration(p.startOffset) * time.Second,
+	}
+	copy(st.Queue, p.Queue)
+	stream := p.stream
+	p.mu.Unlock()
+	if stream != nil {
+		st.Position += stream.PlaybackPosition()
+	}
+	return st
+}
+
+// streamResult reports how a song's stream ended.
+type streamResult int
+
+const (
+	streamFailed   streamResult = iota // The song couldn't be opened or broke off with an error
+	streamFinished                     // The song played to the end
+	streamStopped                      // The song was skipped, stopped or interrupted by a seek
+)
+
+// Consecutive failures back off from failureBackoff, doubling up to maxFailureBackoff.
+const (
+	failureBackoff    = time.Second
+	maxFailureBackoff = 30 * time.Second
+)
+
+// failureDelay is how long to wait before the next song after failures songs in a row failed to play.
+func failureDelay(failures int) time.Duration {
+	if failures < 2 {
+		return 0
+	}
+	delay := failureBackoff
+	for i := 2; i < failures && delay < maxFailureBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxFailureBackoff)
+}
+
 // playLoop continuously dequeues and plays songs until stopped or queue is empty.
 func (p *Player) playLoop() {
 	defer close(p.done)
 
+	var replay *subsonic.Song
+	failures := 0
 	for {
-		// Check for stop signal
+		// Check for stop signal, waiting first if songs keep failing so a broken server or looped queue doesn't spin
+		if delay := failureDelay(failures); delay > 0 {
+			log.Printf("[PLAYER] %d songs failed in a row, waiting %s", failures, delay)
+			select {
+			case <-p.stopChan:
+				return
+			case <-time.After(delay):
+			}
+		}
 		select {
 		case <-p.stopChan:
 			return
 		default:
 		}
 
-		song := p.dequeue()
+		song := replay
+		replay = nil
+		if song == nil {
+			if p.GetLoop() == LoopAutoplay {
+				p.autoplayFill()
+			}
+			song = p.dequeue()
+		}
 		if song == nil {
 			// Queue empty — disconnect and stop
 			p.mu.Lock()
@@ -286,18 +618,39 @@ func (p *Player) playLoop() {
 		p.mu.Lock()
 		p.NowPlaying = song
 		p.IsPlaying = true
+		p.StreamTitle = ""
 		p.mu.Unlock()
+		p.emit(EventTrackChange)
 
 		log.Printf("Now playing: %s - %s", song.Artist, song.Title)
-		p.streamSong(song)
+		result, seekTo := p.streamSong(song, 0)
+		for seekTo >= 0 {
+			result, seekTo = p.streamSong(song, seekTo)
+		}
+		if result == streamFailed {
+			failures++
+		} else {
+			failures = 0
+		}
 
 		// Song finished (either naturally or skipped)
 		p.mu.Lock()
-		// Add to history
-		p.History = append(p.History, song)
-		if len(p.History) > 50 {
-			p.History = p.History[1:]
+		switch {
+		case p.Loop == LoopTrack && result == streamFinished:
+			// Play it again without adding every repeat to history
+			replay = song
+		case p.Loop == LoopQueue && !p.requeued && result != streamFailed:
+			// Songs that failed to open aren't looped, or a broken track would be retried forever
+			p.Queue = append(p.Queue, song)
+			fallthrough
+		default:
+			// Add to history
+			p.History = append(p.History, song)
+			if len(p.History) > 50 {
+				p.History = p.History[1:]
+			}
 		}
+		p.requeued = false
 		p.mu.Unlock()
 
 		// After a song finishes, recreate skip channel for the next song
@@ -309,6 +662,7 @@ func (p *Player) playLoop() {
 		for {
 			select {
 			case <-p.volumeChangeChan:
+			case <-p.seekChan:
 			default:
 				break loop
 			}
@@ -317,38 +671,27 @@ func (p *Player) playLoop() {
 	}
 }
 
-// streamSong streams a single song to the voice connection using dca and a custom PCM pipeline.
-func (p *Player) streamSong(song *subsonic.Song) {
-	// Request transcoding to WAV for direct PCM streaming (includes header for sample rate)
-	streamURL := p.subClient.StreamURL(song.ID) + "&format=wav"
-
+// streamSong streams a single song to the voice connection using dca and a custom PCM pipeline, starting offset seconds in. It reports how the stream ended and the position to restart from if a seek interrupted it (-1 otherwise).
+func (p *Player) streamSong(song *subsonic.Song, offset int) (streamResult, int) {
 	defer p.VoiceConn.Speaking(false) // Ensure speaking is disabled on exit
 
-	// 1. Fetch audio stream securely
-	resp, err := p.subClient.StreamHTTP.Get(streamURL)
-	if err != nil {
-		log.Printf("[PLAYER] ERROR: Failed to fetch stream: %v", err)
-		return
-	}
-	defer resp.Body.Close()
-
-	// 2. Parse WAV Header to get sample rate and advance reader
-	wavHeader, err := ReadWavHeader(resp.Body)
+	// 1. Open the song as raw PCM, from the library or a live stream
+	pcm, sampleRate, closePCM, err := p.openPCM(song, offset)
 	if err != nil {
-		log.Printf("[PLAYER] ERROR: Failed to parse WAV header: %v", err)
-		return
+		log.Printf("[PLAYER] ERROR: %v", err)
+		return streamFailed, -1
 	}
-	log.Printf("[PLAYER] Stream Info: %d Hz, %d channels, %d bits/sample",
-		wavHeader.SampleRate, wavHeader.NumChannels, wavHeader.BitsPerSample)
+	defer closePCM()
 
-	// 3. Setup PCM Scaler directly on the response body
-	pcmScaler := NewPCMVolume(resp.Body)
+	// 2. Setup PCM Scaler directly on the audio
+	pcmScaler := NewPCMVolume(pcm)
 
 	// Frame size: 960 samples * 2 channels * 2 bytes = 3840 bytes (at 48kHz)
 	const frameSize = 3840
 
 	// Create a pipe to connect Scaler output TO Encoder input
 	pipeReader, pipeWriter := io.Pipe()
+	defer pipeReader.Close() // Unblocks the pump if the encoder stops reading
 	go func() {
 		log.Printf("[PLAYER] PCM Pump started")
 		defer pipeWriter.Close()
@@ -372,7 +715,7 @@ func (p *Player) streamSong(song *subsonic.Song) {
 		log.Printf("[PLAYER] PCM Pump finished")
 	}()
 
-	// 4. Start Encoding Process (FFmpeg 2) with RawInput to tell dca to add -f s16le args
+	// 3. Start Encoding Process (FFmpeg 2) with RawInput to tell dca to add -f s16le args
 	encodeOpts := *StdEncodeOptions
 	encodeOpts.RawOutput = true  // We want raw Opus for Discord
 	encodeOpts.RawInput = true   // We are feeding raw PCM
@@ -380,7 +723,7 @@ func (p *Player) streamSong(song *subsonic.Song) {
 	encodeOpts.Bitrate = p.Config.FFmpegBitrate
 	encodeOpts.Application = "audio"
 	encodeOpts.CompressionLevel = p.Config.CompressionLevel
-	encodeOpts.FrameRate = int(wavHeader.SampleRate) // Tell ffmpeg the INPUT sample rate
+	encodeOpts.FrameRate = sampleRate // Tell ffmpeg the INPUT sample rate
 	encodeOpts.Channels = 2
 	encodeOpts.FrameDuration = 20
 	encodeOpts.BufferedFrames = 0 // Extremely low buffer for instant volume changes
@@ -388,7 +731,7 @@ func (p *Player) streamSong(song *subsonic.Song) {
 	encodingSession, err := EncodeMem(pipeReader, &encodeOpts)
 	if err != nil {
 		log.Printf("[PLAYER] ERROR: dca.EncodeMem (encode) failed: %v", err)
-		return
+		return streamFailed, -1
 	}
 
 	p.mu.Lock()
@@ -414,15 +757,24 @@ func (p *Player) streamSong(song *subsonic.Song) {
 	p.mu.Unlock()
 	pcmScaler.SetVolume(currentVol)
 
-	// 5. Send Opus to Discord
+	// 4. Send Opus to Discord
 	doneChan := make(chan error)
 	stream := NewStream(encodingSession, p.VoiceConn, doneChan)
 
 	p.mu.Lock()
 	p.stream = stream
+	p.startOffset = offset
+	paused := p.IsPaused
 	p.mu.Unlock()
+	if paused {
+		stream.SetPaused(true)
+	}
 
 	shouldReturn := false
+	result := streamStopped
+	seekTo := -1
+	progress := time.NewTicker(ProgressInterval)
+	defer progress.Stop()
 
 	// Control Loop
 	for {
@@ -433,6 +785,13 @@ func (p *Player) streamSong(song *subsonic.Song) {
 		case <-p.skipChan:
 			log.Printf("[PLAYER] Skip signal received")
 			shouldReturn = true
+		case seekTo = <-p.seekChan:
+			log.Printf("[PLAYER] Seek signal received")
+			shouldReturn = true
+		case <-progress.C:
+			if !stream.Paused() {
+				p.emit(EventProgress)
+			}
 		case <-p.volumeChangeChan:
 			// Just update scaler volume!
 			p.mu.Lock()
@@ -445,8 +804,10 @@ func (p *Player) streamSong(song *subsonic.Song) {
 		case err := <-doneChan:
 			if err != nil && err != io.EOF {
 				log.Printf("[PLAYER] Stream ended with error: %v", err)
+				result = streamFailed
 			} else {
 				log.Printf("[PLAYER] Stream finished normally")
+				result = streamFinished
 			}
 			shouldReturn = true
 		}
@@ -459,11 +820,7 @@ func (p *Player) streamSong(song *subsonic.Song) {
 	cleanupSession()
 	p.VoiceConn.Speaking(false)
 
-	if shouldReturn {
-		return
-	}
-	// If NOT returning (natural finish), we loop back -> StartTime increased -> New Encoding created Wait, start time won't increase automatically unless we track it And usually natural finish means song done.
-
+	return result, seekTo
 }
 
 // FormatDuration formats seconds into MM:SS.
@@ -0,0 +1,192 @@
+package player
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"soosa/internal/discord"
+	"soosa/internal/subsonic"
+)
+
+func queueTitles(p *Player) string {
+	out := ""
+	for _, song := range p.GetQueue() {
+		out += song.Title
+	}
+	return out
+}
+
+func newTestPlayer(titles string) *Player {
+	p := NewPlayer(nil, nil, "guild", &discord.Config{})
+	for _, t := range titles {
+		p.Queue = append(p.Queue, &subsonic.Song{ID: string(t), Title: string(t)})
+	}
+	return p
+}
+
+func TestQueueEditing(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(p *Player) error
+		want string
+	}{
+		{"remove first", func(p *Player) error { _, err := p.RemoveAt(0); return err }, "BCDE"},
+		{"remove last", func(p *Player) error { _, err := p.RemoveAt(4); return err }, "ABCD"},
+		{"move down", func(p *Player) error { _, err := p.Move(0, 3); return err }, "BCDAE"},
+		{"move up", func(p *Player) error { _, err := p.Move(4, 1); return err }, "AEBCD"},
+		{"move in place", func(p *Player) error { _, err := p.Move(2, 2); return err }, "ABCDE"},
+		{"jump", func(p *Player) error { _, err := p.JumpTo(2); return err }, "CDE"},
+		{"clear", func(p *Player) error { p.ClearQueue(); return nil }, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPlayer("ABCDE")
+			if err := tt.edit(p); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := queueTitles(p); got != tt.want {
+				t.Errorf("queue = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueuePositionOutOfRange(t *testing.T) {
+	p := newTestPlayer("ABC")
+	if _, err := p.RemoveAt(3); err != ErrQueuePosition {
+		t.Errorf("RemoveAt(3) error = %v, want ErrQueuePosition", err)
+	}
+	if _, err := p.Move(-1, 0); err != ErrQueuePosition {
+		t.Errorf("Move(-1, 0) error = %v, want ErrQueuePosition", err)
+	}
+	if _, err := p.JumpTo(5); err != ErrQueuePosition {
+		t.Errorf("JumpTo(5) error = %v, want ErrQueuePosition", err)
+	}
+	if got := queueTitles(p); got != "ABC" {
+		t.Errorf("queue changed to %q after rejected edits", got)
+	}
+}
+
+func TestShuffleKeepsSongs(t *testing.T) {
+	p := newTestPlayer("ABCDEFGH")
+	if n := p.Shuffle(); n != 8 {
+		t.Errorf("Shuffle() = %d, want 8", n)
+	}
+	seen := make(map[string]bool)
+	for _, song := range p.GetQueue() {
+		seen[song.ID] = true
+	}
+	if len(seen) != 8 {
+		t.Errorf("shuffle lost songs: %v", seen)
+	}
+}
+
+func TestLoopModes(t *testing.T) {
+	p := newTestPlayer("")
+	want := []LoopMode{LoopTrack, LoopQueue, LoopAutoplay, LoopOff}
+	for _, w := range want {
+		if got := p.CycleLoop(); got != w {
+			t.Errorf("CycleLoop() = %s, want %s", got, w)
+		}
+	}
+
+	for _, m := range loopModes {
+		if got, ok := ParseLoopMode(m.String()); !ok || got != m {
+			t.Errorf("ParseLoopMode(%q) = %s, %v", m.String(), got, ok)
+		}
+	}
+	if _, ok := ParseLoopMode("forever"); ok {
+		t.Error("ParseLoopMode accepted an unknown mode")
+	}
+}
+
+func TestFailureDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 0},
+		{2, time.Second},
+		{3, 2 * time.Second},
+		{5, 8 * time.Second},
+		{7, 30 * time.Second},
+		{100, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := failureDelay(tt.failures); got != tt.want {
+			t.Errorf("failureDelay(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestPickAutoplaySongs(t *testing.T) {
+	recent := map[string]bool{"a": true}
+	candidates := []subsonic.Song{{ID: "a"}, {ID: "b"}, {ID: ""}, {ID: "c"}, {ID: "d"}}
+
+	picked := pickAutoplaySongs(candidates, recent, 2)
+	if len(picked) != 2 || picked[0].ID != "b" || picked[1].ID != "c" {
+		t.Fatalf("picked %v, want b and c", picked)
+	}
+
+	// Songs picked from one source aren't picked again from the next
+	more := pickAutoplaySongs([]subsonic.Song{{ID: "c"}, {ID: "d"}}, recent, 5)
+	if len(more) != 1 || more[0].ID != "d" {
+		t.Errorf("second pick = %v, want only d", more)
+	}
+}
+
+func TestEmitDeliversInOrder(t *testing.T) {
+	p := newTestPlayer("")
+	var (
+		mu      sync.Mutex
+		got     []Event
+		running int
+		overlap bool
+	)
+	done := make(chan struct{})
+	p.onEvent = func(_ *discordgo.Session, _ *Player, event Event) {
+		mu.Lock()
+		running++
+		overlap = overlap || running > 1
+		first := len(got) == 0
+		mu.Unlock()
+
+		// The first handler is slow, as an edit that hits a rate limit would be
+		if first {
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		mu.Lock()
+		running--
+		got = append(got, event)
+		if len(got) == 3 {
+			close(done)
+		}
+		mu.Unlock()
+	}
+
+	p.emit(EventProgress)
+	p.emit(EventTrackChange)
+	p.emit(EventTrackChange) // Same as the one still waiting, so dropped
+	p.emit(EventProgress)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("events were not delivered")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []Event{EventProgress, EventTrackChange, EventProgress}; !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if overlap {
+		t.Error("handlers ran at the same time")
+	}
+}
@@ -0,0 +1,197 @@
+package player
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RadioSource plays an internet radio station. Stations that send ICY metadata have it stripped from the audio and their stream titles shown as the now-playing text.
+type RadioSource struct {
+	URL string
+	// External marks streams from links users gave, which may only reach public addresses
+	External bool
+}
+
+// NewRadioSource returns a source for the station streaming at url.
+func NewRadioSource(url string) *RadioSource {
+	return &RadioSource{URL: url}
+}
+
+// Live implements subsonic.Source. Radio never ends and can't be seeked.
+func (r *RadioSource) Live() bool {
+	return true
+}
+
+// Open implements subsonic.Source by connecting to the station and decoding it to PCM. The offset is ignored.
+func (r *RadioSource) Open(offset int, onTitle func(string)) (io.Reader, int, func(), error) {
+	req, err := http.NewRequest(http.MethodGet, r.URL, nil)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("invalid stream URL: %w", err)
+	}
+	req.Header.Set("Icy-MetaData", "1")
+
+	var resp *http.Response
+	if r.External {
+		if resp, err = openURL(req); err != nil {
+			return nil, 0, nil, err
+		}
+	} else {
+		if resp, err = stationHTTP.Do(req); err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to connect to station: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, 0, nil, fmt.Errorf("station returned %s", resp.Status)
+		}
+	}
+
+	var audio io.Reader = resp.Body
+	if metaInt, err := strconv.Atoi(resp.Header.Get("icy-metaint")); err == nil && metaInt > 0 {
+		audio = newICYReader(resp.Body, metaInt, onTitle)
+	}
+	log.Printf("[PLAYER] Connected to station %s (%s)", r.URL, resp.Header.Get("Content-Type"))
+
+	decoder, err := EncodeMem(audio, decodeOptions(0))
+	if err != nil {
+		resp.Body.Close()
+		return nil, 0, nil, fmt.Errorf("failed to start decoder: %w", err)
+	}
+	return decoder, StdEncodeOptions.FrameRate, func() {
+		resp.Body.Close()
+		decoder.Cleanup()
+	}, nil
+}
+
+// icyStatus is how SHOUTcast v1 servers start their response, in place of an HTTP version, which net/http refuses.
+var icyStatus = []byte("ICY ")
+
+// icyConn reads a connection, turning an "ICY 200 OK" status line into "HTTP/1.0 200 OK" so net/http can parse the rest of the response. Anything else, including TLS, passes through untouched.
+type icyConn struct {
+	net.Conn
+	checked bool
+	pending []byte // Bytes already read from the start of the connection, or their replacement
+}
+
+// Read implements net.Conn.
+func (c *icyConn) Read(p []byte) (int, error) {
+	if !c.checked {
+		c.checked = true
+		head := make([]byte, len(icyStatus))
+		n, err := io.ReadFull(c.Conn, head)
+		if n == 0 && err != nil {
+			return 0, err
+		}
+		c.pending = head[:n]
+		if bytes.Equal(c.pending, icyStatus) {
+			c.pending = []byte("HTTP/1.0 ")
+		}
+	}
+	if len(c.pending) > 0 {
+		n := copy(p, c.pending)
+		c.pending = c.pending[n:]
+		return n, nil
+	}
+	return c.Conn.Read(p)
+}
+
+// icyDial wraps dial so every connection it makes understands SHOUTcast v1 status lines.
+func icyDial(dial func(ctx context.Context, network, address string) (net.Conn, error)) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		return &icyConn{Conn: conn}, nil
+	}
+}
+
+// newStationClient returns a client for stations on the Subsonic server's list, which may be SHOUTcast v1 servers.
+func newStationClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = icyDial((&net.Dialer{Timeout: dialTimeout}).DialContext)
+	return &http.Client{Transport: transport}
+}
+
+// setStreamTitle records a new stream title and lets listeners know the track changed.
+func (p *Player) setStreamTitle(title string) {
+	p.mu.Lock()
+	changed := p.StreamTitle != title
+	p.StreamTitle = title
+	p.mu.Unlock()
+
+	if changed {
+		log.Printf("[PLAYER] Stream title: %s", title)
+		p.emit(EventTrackChange)
+	}
+}
+
+// GetStreamTitle returns what the current live stream says it's playing, if anything.
+func (p *Player) GetStreamTitle() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.StreamTitle
+}
+
+// icyReader strips SHOUTcast/Icecast metadata blocks out of a stream, passing their titles to onTitle. A length byte and metadata follow every metaInt bytes of audio.
+type icyReader struct {
+	r         io.Reader
+	metaInt   int
+	remaining int // Audio bytes left before the next metadata block
+	onTitle   func(string)
+}
+
+func newICYReader(r io.Reader, metaInt int, onTitle func(string)) *icyReader {
+	return &icyReader{r: r, metaInt: metaInt, remaining: metaInt, onTitle: onTitle}
+}
+
+// Read implements io.Reader, returning only audio bytes.
+func (ir *icyReader) Read(p []byte) (int, error) {
+	if ir.remaining == 0 {
+		var length [1]byte
+		if _, err := io.ReadFull(ir.r, length[:]); err != nil {
+			return 0, err
+		}
+		if size := int(length[0]) * 16; size > 0 {
+			meta := make([]byte, size)
+			if _, err := io.ReadFull(ir.r, meta); err != nil {
+				return 0, err
+			}
+			if title := parseStreamTitle(string(meta)); title != "" && ir.onTitle != nil {
+				ir.onTitle(title)
+			}
+		}
+		ir.remaining = ir.metaInt
+	}
+
+	if len(p) > ir.remaining {
+		p = p[:ir.remaining]
+	}
+	n, err := ir.r.Read(p)
+	ir.remaining -= n
+	return n, err
+}
+
+// parseStreamTitle pulls StreamTitle out of an ICY metadata block like "StreamTitle='Artist - Song';StreamUrl=”;".
+func parseStreamTitle(meta string) string {
+	const key = "StreamTitle='"
+	start := strings.Index(meta, key)
+	if start < 0 {
+		return ""
+	}
+	rest := meta[start+len(key):]
+	end := strings.Index(rest, "';")
+	if end < 0 {
+		end = strings.LastIndex(rest, "'")
+	}
+	if end < 0 {
+		return ""
+	}
+	return strings.TrimSpace(rest[:end])
+}
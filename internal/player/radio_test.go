@@ -0,0 +1,98 @@
+package player
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestParseStreamTitle(t *testing.T) {
+	tests := []struct {
+		meta string
+		want string
+	}{
+		{"StreamTitle='Artist - Song';StreamUrl='';", "Artist - Song"},
+		{"StreamTitle='It's Here';", "It's Here"},
+		{"StreamTitle='';", ""},
+		{"StreamUrl='http://example.com';", ""},
+		{"StreamTitle='No terminator'\x00\x00", "No terminator"},
+	}
+	for _, tt := range tests {
+		if got := parseStreamTitle(tt.meta); got != tt.want {
+			t.Errorf("parseStreamTitle(%q) = %q, want %q", tt.meta, got, tt.want)
+		}
+	}
+}
+
+func TestICYReaderStripsMetadata(t *testing.T) {
+	meta := []byte("StreamTitle='Hello';")
+	block := append([]byte{2}, append(meta, make([]byte, 32-len(meta))...)...)
+
+	var stream bytes.Buffer
+	stream.WriteString("abcd")
+	stream.Write(block)
+	stream.WriteString("efgh")
+	stream.WriteByte(0) // Empty metadata block
+	stream.WriteString("ij")
+
+	var titles []string
+	r := newICYReader(&stream, 4, func(title string) { titles = append(titles, title) })
+	audio, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(audio) != "abcdefghij" {
+		t.Errorf("audio = %q, want %q", audio, "abcdefghij")
+	}
+	if len(titles) != 1 || titles[0] != "Hello" {
+		t.Errorf("titles = %v, want [Hello]", titles)
+	}
+}
+
+func TestStationClientReadsICYStatusLine(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// A SHOUTcast v1 server answers with its own status line and closes the connection at the end of the stream
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		http.ReadRequest(bufio.NewReader(conn))
+		io.WriteString(conn, "ICY 200 OK\r\nicy-name: Test FM\r\nicy-metaint: 8192\r\n\r\naudio")
+	}()
+
+	resp, err := stationHTTP.Get("http://" + listener.Addr().String() + "/")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("icy-metaint") != "8192" || string(body) != "audio" {
+		t.Errorf("got %d, metaint %q, body %q", resp.StatusCode, resp.Header.Get("icy-metaint"), body)
+	}
+}
+
+func TestICYConnLeavesHTTPAlone(t *testing.T) {
+	server, client := net.Pipe()
+	go func() {
+		io.WriteString(server, "HTTP/1.1 200 OK\r\n")
+		server.Close()
+	}()
+
+	got, err := io.ReadAll(&icyConn{Conn: client})
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "HTTP/1.1 200 OK\r\n" {
+		t.Errorf("got %q", got)
+	}
+}
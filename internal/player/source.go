@@ -0,0 +1,322 @@
+package player
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"soosa/internal/subsonic"
+)
+
+// ErrNoMusicDir is returned for local files when MUSIC_DIR isn't configured.
+var ErrNoMusicDir = errors.New("no local music directory is configured")
+
+// ErrOutsideMusicDir is returned for local paths that would escape the music directory.
+var ErrOutsideMusicDir = errors.New("that path is outside the music directory")
+
+// ErrInvalidURL is returned for audio URLs that aren't plain http or https links.
+var ErrInvalidURL = errors.New("only http and https links can be played")
+
+const probeTimeout = 20 * time.Second
+
+// sourceHTTP fetches audio from links users give /play url. It refuses private addresses, and since streams can run for hours it has no timeout.
+var sourceHTTP = newSourceClient(blockedIP)
+
+// stationHTTP fetches the internet radio stations configured on the Subsonic server, which may well be on the local network.
+var stationHTTP = newStationClient()
+
+// audioExtensions are the local files offered to /play file.
+var audioExtensions = map[string]bool{
+	".mp3": true, ".flac": true, ".ogg": true, ".opus": true, ".m4a": true,
+	".aac": true, ".wav": true, ".wma": true, ".alac": true, ".aiff": true,
+}
+
+// openPCM opens a song as signed 16-bit stereo PCM starting offset seconds in. It returns the audio, its sample rate and a function that releases it.
+func (p *Player) openPCM(song *subsonic.Song, offset int) (io.Reader, int, func(), error) {
+	if song.Source != nil {
+		return song.Source.Open(offset, p.setStreamTitle)
+	}
+
+	// Request transcoding to WAV for direct PCM streaming (includes header for sample rate)
+	streamURL := p.subClient.StreamURL(song.ID) + "&format=wav"
+	if offset > 0 {
+		streamURL += fmt.Sprintf("&timeOffset=%d", offset)
+	}
+
+	resp, err := p.subClient.StreamHTTP.Get(streamURL)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to fetch stream: %w", err)
+	}
+
+	// Parse WAV Header to get sample rate and advance reader
+	wavHeader, err := ReadWavHeader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, 0, nil, fmt.Errorf("failed to parse WAV header: %w", err)
+	}
+	log.Printf("[PLAYER] Stream Info: %d Hz, %d channels, %d bits/sample",
+		wavHeader.SampleRate, wavHeader.NumChannels, wavHeader.BitsPerSample)
+
+	return resp.Body, int(wavHeader.SampleRate), func() { resp.Body.Close() }, nil
+}
+
+// decodeOptions returns options for an ffmpeg session that turns any audio ffmpeg understands into the PCM the player scales and encodes.
+func decodeOptions(offset int) *EncodeOptions {
+	opts := *StdEncodeOptions
+	opts.PCMOutput = true
+	opts.RawOutput = true
+	opts.StartTime = offset
+	return &opts
+}
+
+// FileSource plays a local file or a direct link to an audio file. Files are handed straight to ffmpeg, while links are fetched by the bot and piped in.
+type FileSource struct {
+	Location string
+}
+
+// Live implements subsonic.Source. Files have a length and can be seeked.
+func (f *FileSource) Live() bool {
+	return false
+}
+
+// Open implements subsonic.Source by decoding the file with ffmpeg from offset seconds in. Links are fetched again each time, in case where they lead has changed.
+func (f *FileSource) Open(offset int, onTitle func(string)) (io.Reader, int, func(), error) {
+	if !isURLInput(f.Location) {
+		decoder, err := EncodeFile(f.Location, decodeOptions(offset))
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to start decoder: %w", err)
+		}
+		return decoder, StdEncodeOptions.FrameRate, decoder.Cleanup, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, f.Location, nil)
+	if err != nil {
+		return nil, 0, nil, ErrInvalidURL
+	}
+	resp, err := openURL(req)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	decoder, err := EncodeMem(resp.Body, decodeOptions(offset))
+	if err != nil {
+		resp.Body.Close()
+		return nil, 0, nil, fmt.Errorf("failed to start decoder: %w", err)
+	}
+	return decoder, StdEncodeOptions.FrameRate, func() {
+		resp.Body.Close()
+		decoder.Cleanup()
+	}, nil
+}
+
+// ResolveLocalFile turns a path relative to root into the file it names. Symlinks are followed before checking, so nothing outside root can be reached, and only regular files are accepted.
+func ResolveLocalFile(root, name string) (string, error) {
+	if root == "" {
+		return "", ErrNoMusicDir
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("music directory unavailable: %w", err)
+	}
+	realRoot, err = filepath.Abs(realRoot)
+	if err != nil {
+		return "", fmt.Errorf("music directory unavailable: %w", err)
+	}
+
+	target, err := filepath.EvalSymlinks(filepath.Join(realRoot, filepath.FromSlash(name)))
+	if err != nil {
+		return "", fmt.Errorf("no file called %s", name)
+	}
+	rel, err := filepath.Rel(realRoot, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", ErrOutsideMusicDir
+	}
+
+	info, err := os.Stat(target)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s isn't a file", name)
+	}
+	return target, nil
+}
+
+// ListLocalFiles returns every audio file under root as slash-separated paths relative to root, in lexical order.
+func ListLocalFiles(root string) []string {
+	if root == "" {
+		return nil
+	}
+
+	var files []string
+	filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !audioExtensions[strings.ToLower(filepath.Ext(p))] {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files
+}
+
+// FilterLocalFiles returns up to limit of files whose paths contain query, ignoring case.
+func FilterLocalFiles(files []string, query string, limit int) []string {
+	query = strings.ToLower(query)
+	var out []string
+	for _, file := range files {
+		if len(out) >= limit {
+			break
+		}
+		if strings.Contains(strings.ToLower(file), query) {
+			out = append(out, file)
+		}
+	}
+	return out
+}
+
+// NewLocalSong probes a file in root and returns a song that plays it. name is relative to root.
+func NewLocalSong(root, name string) (*subsonic.Song, error) {
+	filePath, err := ResolveLocalFile(root, name)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := probeMetadata(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %w", name, err)
+	}
+
+	song := songFromProbe(meta, strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath)))
+	song.ID = "file:" + filepath.ToSlash(name)
+	song.Source = &FileSource{Location: filePath}
+	return song, nil
+}
+
+// NewURLSong probes a web link and returns a song that plays it. Links that report no duration are treated as live streams and played like a radio station. Links to local or private addresses, directly or through a redirect, are refused.
+func NewURLSong(link string) (*subsonic.Song, error) {
+	if err := CheckURL(link); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+	resp, err := openURL(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	meta, err := probeStream(ctx, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read that link: %w", err)
+	}
+
+	fallback := path.Base(resp.Request.URL.Path)
+	if fallback == "/" || fallback == "." {
+		fallback = resp.Request.URL.Host
+	}
+	song := songFromProbe(meta, strings.TrimSuffix(fallback, path.Ext(fallback)))
+	if song.Duration == 0 {
+		song.Duration = estimateDuration(meta, resp.ContentLength)
+	}
+	song.ID = link
+	if song.Duration > 0 {
+		song.Source = &FileSource{Location: link}
+	} else {
+		song.Source = &RadioSource{URL: link, External: true}
+	}
+	return song, nil
+}
+
+// probeMetadata reads the container format and tags of a local file with ffprobe.
+func probeMetadata(location string) (*FFprobeMetadata, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	return runProbe(ctx, location, nil)
+}
+
+// probeStream reads the container format and tags of audio piped in from r. ffprobe only reads what it needs, so r may be an endless stream.
+func probeStream(ctx context.Context, r io.Reader) (*FFprobeMetadata, error) {
+	return runProbe(ctx, "pipe:0", r)
+}
+
+// runProbe runs ffprobe on input, feeding it stdin when input is a pipe.
+func runProbe(ctx context.Context, input string, stdin io.Reader) (*FFprobeMetadata, error) {
+	var out bytes.Buffer
+	args := append([]string{"-v", "quiet", "-print_format", "json", "-show_format"}, protocolArgs(input)...)
+	ffprobe := exec.CommandContext(ctx, "ffprobe", append(args, input)...)
+	ffprobe.Stdin = stdin
+	ffprobe.Stdout = &out
+	// ffprobe exits without draining its input, so don't wait for a stream that may never end to be copied in
+	ffprobe.WaitDelay = time.Second
+	if err := ffprobe.Run(); err != nil && !errors.Is(err, exec.ErrWaitDelay) {
+		return nil, fmt.Errorf("ffprobe failed: %w", err)
+	}
+	return parseProbeOutput(out.Bytes())
+}
+
+// estimateDuration works out how long piped audio lasts from its size and bitrate, since ffprobe can't see the size of a pipe. It returns 0 when either is unknown, as for live streams.
+func estimateDuration(meta *FFprobeMetadata, size int64) int {
+	bitrate, err := strconv.ParseFloat(meta.Format.Bitrate, 64)
+	if err != nil || bitrate <= 0 || size <= 0 {
+		return 0
+	}
+	return int(float64(size) * 8 / bitrate)
+}
+
+// parseProbeOutput decodes ffprobe's JSON, filling in empty format and tag sections so callers don't have to check.
+func parseProbeOutput(data []byte) (*FFprobeMetadata, error) {
+	var meta FFprobeMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("invalid ffprobe output: %w", err)
+	}
+	if meta.Format == nil {
+		return nil, errors.New("not an audio file")
+	}
+	if meta.Format.Tags == nil {
+		meta.Format.Tags = &FFprobeTags{}
+	}
+	return &meta, nil
+}
+
+// songFromProbe builds a song from probed tags, using fallbackTitle when the file has no title of its own.
+func songFromProbe(meta *FFprobeMetadata, fallbackTitle string) *subsonic.Song {
+	tags := meta.Format.Tags
+	song := &subsonic.Song{
+		Title:  tags.Title,
+		Artist: tags.Artist,
+		Album:  tags.Album,
+		Genre:  tags.Genre,
+	}
+	if song.Title == "" {
+		song.Title = fallbackTitle
+	}
+	if song.Artist == "" {
+		song.Artist = "Unknown artist"
+	}
+	if seconds, err := strconv.ParseFloat(meta.Format.Duration, 64); err == nil && seconds > 0 {
+		song.Duration = int(seconds)
+	}
+	if len(tags.Date) >= 4 {
+		song.Year, _ = strconv.Atoi(tags.Date[:4])
+	}
+	// Track tags are often written as "3/12"
+	track, _, _ := strings.Cut(tags.Track, "/")
+	song.Track, _ = strconv.Atoi(track)
+	return song
+}
@@ -0,0 +1,251 @@
+package player
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolveLocalFile(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "music")
+	os.MkdirAll(filepath.Join(root, "Album"), 0o755)
+	os.WriteFile(filepath.Join(root, "Album", "01 Song.flac"), []byte("x"), 0o644)
+	os.WriteFile(filepath.Join(base, "secret.mp3"), []byte("x"), 0o644)
+	if err := os.Symlink(filepath.Join(base, "secret.mp3"), filepath.Join(root, "escape.mp3")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		wantErr error
+		ok      bool
+	}{
+		{"Album/01 Song.flac", nil, true},
+		{"/Album/01 Song.flac", nil, true},
+		{"Album/../Album/01 Song.flac", nil, true},
+		{"../secret.mp3", ErrOutsideMusicDir, false},
+		{"Album/../../secret.mp3", ErrOutsideMusicDir, false},
+		{"escape.mp3", ErrOutsideMusicDir, false},
+		{"Album", nil, false},
+		{"missing.mp3", nil, false},
+	}
+	for _, tt := range tests {
+		got, err := ResolveLocalFile(root, tt.name)
+		if tt.ok {
+			if err != nil || filepath.Base(got) != "01 Song.flac" {
+				t.Errorf("ResolveLocalFile(%q) = %q, %v", tt.name, got, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("ResolveLocalFile(%q) = %q, want an error", tt.name, got)
+		} else if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("ResolveLocalFile(%q) error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if _, err := ResolveLocalFile("", "song.mp3"); !errors.Is(err, ErrNoMusicDir) {
+		t.Errorf("ResolveLocalFile with no root error = %v, want %v", err, ErrNoMusicDir)
+	}
+}
+
+func TestListLocalFiles(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "Band", "Album"), 0o755)
+	for _, name := range []string{"Band/Album/01 Intro.mp3", "Band/Album/02 Outro.FLAC", "Band/Album/cover.jpg", "single.ogg"} {
+		os.WriteFile(filepath.Join(root, filepath.FromSlash(name)), []byte("x"), 0o644)
+	}
+
+	files := ListLocalFiles(root)
+	if want := []string{"Band/Album/01 Intro.mp3", "Band/Album/02 Outro.FLAC", "single.ogg"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("ListLocalFiles = %v, want %v", files, want)
+	}
+	if got := ListLocalFiles(""); got != nil {
+		t.Errorf("ListLocalFiles with no root = %v, want nil", got)
+	}
+
+	if got, want := FilterLocalFiles(files, "band", 25), []string{"Band/Album/01 Intro.mp3", "Band/Album/02 Outro.FLAC"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterLocalFiles(band) = %v, want %v", got, want)
+	}
+	if got := FilterLocalFiles(files, "", 2); len(got) != 2 {
+		t.Errorf("FilterLocalFiles with limit 2 returned %d files", len(got))
+	}
+}
+
+func TestSongFromProbe(t *testing.T) {
+	meta, err := parseProbeOutput([]byte(`{"format": {"filename": "a.flac", "duration": "245.826000",
+		"tags": {"TITLE": "Song", "ARTIST": "Band", "ALBUM": "Record", "DATE": "1999-05-01", "track": "3/12"}}}`))
+	if err != nil {
+		t.Fatalf("parseProbeOutput: %v", err)
+	}
+	song := songFromProbe(meta, "a")
+	if song.Title != "Song" || song.Artist != "Band" || song.Album != "Record" ||
+		song.Duration != 245 || song.Year != 1999 || song.Track != 3 {
+		t.Errorf("songFromProbe = %+v", song)
+	}
+
+	meta, err = parseProbeOutput([]byte(`{"format": {"filename": "http://radio.example/live", "duration": "N/A"}}`))
+	if err != nil {
+		t.Fatalf("parseProbeOutput: %v", err)
+	}
+	song = songFromProbe(meta, "live")
+	if song.Title != "live" || song.Artist != "Unknown artist" || song.Duration != 0 {
+		t.Errorf("songFromProbe without tags = %+v", song)
+	}
+
+	if _, err := parseProbeOutput([]byte(`{}`)); err == nil {
+		t.Error("parseProbeOutput without a format should fail")
+	}
+}
+
+func TestReconnectArgs(t *testing.T) {
+	if args := reconnectArgs("https://example.com/a.mp3"); len(args) == 0 {
+		t.Error("reconnectArgs should return flags for https links")
+	}
+	for _, in := range []string{"/music/a.mp3", "pipe:0"} {
+		if args := reconnectArgs(in); args != nil {
+			t.Errorf("reconnectArgs(%q) = %v, want none", in, args)
+		}
+	}
+}
+
+func TestProtocolArgs(t *testing.T) {
+	want := []string{"-protocol_whitelist", "http,https,tcp,tls"}
+	if args := protocolArgs("http://example.com/a.mp3"); !reflect.DeepEqual(args, want) {
+		t.Errorf("protocolArgs = %v, want %v", args, want)
+	}
+	if args := protocolArgs("pipe:0"); !reflect.DeepEqual(args, []string{"-protocol_whitelist", "pipe"}) {
+		t.Errorf("protocolArgs for a pipe = %v, want only the pipe allowed", args)
+	}
+	if args := protocolArgs("/music/a.mp3"); args != nil {
+		t.Errorf("protocolArgs for a file = %v, want none", args)
+	}
+}
+
+func TestBlockedIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"0.0.0.0", true},
+		{"100.64.0.1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:10.0.0.1", true},
+		{"8.8.8.8", false},
+		{"2606:4700:4700::1111", false},
+	}
+	for _, tt := range tests {
+		if got := blockedIP(netip.MustParseAddr(tt.ip)); got != tt.blocked {
+			t.Errorf("blockedIP(%s) = %v, want %v", tt.ip, got, tt.blocked)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		link string
+		want error
+	}{
+		{"ftp://example.com/a.mp3", ErrInvalidURL},
+		{"file:///etc/passwd", ErrInvalidURL},
+		{"http://", ErrInvalidURL},
+		{"http://127.0.0.1:8080/a.mp3", ErrBlockedAddress},
+		{"http://[::1]/a.mp3", ErrBlockedAddress},
+		{"http://169.254.169.254/latest/meta-data", ErrBlockedAddress},
+		{"http://localhost/a.mp3", ErrBlockedAddress},
+		{"https://93.184.215.14/a.mp3", nil},
+	}
+	for _, tt := range tests {
+		if err := CheckURL(tt.link); !errors.Is(err, tt.want) {
+			t.Errorf("CheckURL(%q) = %v, want %v", tt.link, err, tt.want)
+		}
+	}
+}
+
+func TestSourceHTTPRefusesLocalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	if _, err := sourceHTTP.Get(srv.URL); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("sourceHTTP.Get(%s) = %v, want %v", srv.URL, err, ErrBlockedAddress)
+	}
+	if _, _, _, err := (&FileSource{Location: srv.URL}).Open(0, nil); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("FileSource.Open(%s) = %v, want %v", srv.URL, err, ErrBlockedAddress)
+	}
+}
+
+func TestSourceHTTPBlocksLateRedirects(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		t.Skipf("127.0.0.2 unavailable: %v", err)
+	}
+	var internalHits atomic.Int32
+	internal := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHits.Add(1)
+	}))
+	internal.Listener.Close()
+	internal.Listener = listener
+	internal.Start()
+	defer internal.Close()
+
+	// The link plays fine the first time it's fetched, then starts redirecting somewhere internal
+	var requests atomic.Int32
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Write([]byte("audio"))
+			return
+		}
+		http.Redirect(w, r, internal.URL+"/latest/meta-data", http.StatusFound)
+	}))
+	defer public.Close()
+
+	saved := sourceHTTP
+	defer func() { sourceHTTP = saved }()
+	sourceHTTP = newSourceClient(func(ip netip.Addr) bool { return ip == netip.MustParseAddr("127.0.0.2") })
+
+	req, _ := http.NewRequest(http.MethodGet, public.URL, nil)
+	resp, err := openURL(req)
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, _, _, err := (&FileSource{Location: public.URL}).Open(0, nil); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("FileSource.Open after redirect = %v, want %v", err, ErrBlockedAddress)
+	}
+	if _, _, _, err := (&RadioSource{URL: public.URL, External: true}).Open(0, nil); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("RadioSource.Open after redirect = %v, want %v", err, ErrBlockedAddress)
+	}
+	if n := internalHits.Load(); n != 0 {
+		t.Errorf("internal server was reached %d times", n)
+	}
+}
+
+func TestEstimateDuration(t *testing.T) {
+	meta := &FFprobeMetadata{Format: &FFprobeFormat{Bitrate: "128000"}}
+	if got := estimateDuration(meta, 3_840_000); got != 240 {
+		t.Errorf("estimateDuration = %d, want 240", got)
+	}
+	if got := estimateDuration(meta, -1); got != 0 {
+		t.Errorf("estimateDuration with unknown size = %d, want 0", got)
+	}
+	if got := estimateDuration(&FFprobeMetadata{Format: &FFprobeFormat{Bitrate: "N/A"}}, 1000); got != 0 {
+		t.Errorf("estimateDuration with unknown bitrate = %d, want 0", got)
+	}
+}
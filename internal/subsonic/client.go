@@ -174,6 +174,74 @@ func (c *Client) GetRandomSongs(count int) ([]Song, error) {
 	return resp.RandomSongs.Songs, nil
 }
 
+// GetTopSongs returns the most played songs by an artist, looked up by name.
+func (c *Client) GetTopSongs(artist string, count int) ([]Song, error) {
+	params := url.Values{}
+	params.Set("artist", artist)
+	params.Set("count", fmt.Sprintf("%d", count))
+
+	resp, err := c.get("getTopSongs.view", params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.TopSongs == nil {
+		return []Song{}, nil
+	}
+	return resp.TopSongs.Songs, nil
+}
+
+// GetSimilarSongs2 returns songs by the given artist and artists similar to them, using last.fm data on the server.
+func (c *Client) GetSimilarSongs2(artistID string, count int) ([]Song, error) {
+	params := url.Values{}
+	params.Set("id", artistID)
+	params.Set("count", fmt.Sprintf("%d", count))
+
+	resp, err := c.get("getSimilarSongs2.view", params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.SimilarSongs2 == nil {
+		return []Song{}, nil
+	}
+	return resp.SimilarSongs2.Songs, nil
+}
+
+// GetInternetRadioStations returns the radio stations configured on the server.
+func (c *Client) GetInternetRadioStations() ([]InternetRadioStation, error) {
+	resp, err := c.get("getInternetRadioStations.view", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.InternetRadioStations == nil {
+		return []InternetRadioStation{}, nil
+	}
+	return resp.InternetRadioStations.Stations, nil
+}
+
+// GetPlaylists returns the playlists visible to the user, without their songs.
+func (c *Client) GetPlaylists() ([]Playlist, error) {
+	resp, err := c.get("getPlaylists.view", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Playlists == nil {
+		return []Playlist{}, nil
+	}
+	return resp.Playlists.Playlists, nil
+}
+
+// GetPlaylist returns a playlist with its songs.
+func (c *Client) GetPlaylist(id string) (*Playlist, error) {
+	params := url.Values{}
+	params.Set("id", id)
+
+	resp, err := c.get("getPlaylist.view", params)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Playlist, nil
+}
+
 // StreamURL returns the authenticated URL for streaming a song. This URL is NOT fetched by the client — it's passed to ffmpeg/dca.
 
 func (c *Client) StreamURL(id string) string {
@@ -14,6 +14,12 @@ type ResponseBody struct {
 	Album         *Album         `json:"album,omitempty"`
 	Artist        *ArtistFull    `json:"artist,omitempty"`
 	RandomSongs   *RandomSongs   `json:"randomSongs,omitempty"`
+	TopSongs      *TopSongs      `json:"topSongs,omitempty"`
+	SimilarSongs2 *SimilarSongs2 `json:"similarSongs2,omitempty"`
+	Playlists     *Playlists     `json:"playlists,omitempty"`
+	Playlist      *Playlist      `json:"playlist,omitempty"`
+
+	InternetRadioStations *InternetRadioStations `json:"internetRadioStations,omitempty"`
 }
 
 type APIError struct {
@@ -32,6 +38,7 @@ type Song struct {
 	Title    string `json:"title"`
 	Album    string `json:"album,omitempty"`
 	Artist   string `json:"artist,omitempty"`
+	ArtistID string `json:"artistId,omitempty"`
 	Duration int    `json:"duration,omitempty"` // seconds
 	CoverArt string `json:"coverArt,omitempty"`
 	Year     int    `json:"year,omitempty"`
@@ -39,6 +46,9 @@ type Song struct {
 	Track    int    `json:"track,omitempty"`
 	BitRate  int    `json:"bitRate,omitempty"`
 	Suffix   string `json:"suffix,omitempty"`
+
+	// Source is set for songs that don't come from the library, such as radio stations, web URLs and local files. Library songs leave it nil and are streamed with stream.view.
+	Source Source `json:"-"`
 }
 
 type Album struct {
@@ -72,3 +82,37 @@ type ArtistFull struct {
 type RandomSongs struct {
 	Songs []Song `json:"song,omitempty"`
 }
+
+type TopSongs struct {
+	Songs []Song `json:"song,omitempty"`
+}
+
+type SimilarSongs2 struct {
+	Songs []Song `json:"song,omitempty"`
+}
+
+type InternetRadioStations struct {
+	Stations []InternetRadioStation `json:"internetRadioStation,omitempty"`
+}
+
+type InternetRadioStation struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	StreamURL   string `json:"streamUrl"`
+	HomePageURL string `json:"homePageUrl,omitempty"`
+}
+
+type Playlists struct {
+	Playlists []Playlist `json:"playlist,omitempty"`
+}
+
+type Playlist struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Owner     string `json:"owner,omitempty"`
+	Public    bool   `json:"public,omitempty"`
+	SongCount int    `json:"songCount,omitempty"`
+	Duration  int    `json:"duration,omitempty"`
+	CoverArt  string `json:"coverArt,omitempty"`
+	Songs     []Song `json:"entry,omitempty"`
+}
@@ -0,0 +1,16 @@
+package subsonic
+
+import "io"
+
+// Source provides the audio for a song that isn't streamed from the library. The player package implements it for internet radio, web URLs and local files.
+type Source interface {
+	// Open returns the audio as signed 16-bit stereo PCM starting offset seconds in, its sample rate and a function that releases it. onTitle is called whenever a live stream announces a new title.
+	Open(offset int, onTitle func(string)) (io.Reader, int, func(), error)
+	// Live reports whether the source is an endless stream with no duration, which can't be seeked.
+	Live() bool
+}
+
+// IsLive reports whether a song is a live stream rather than a track with a length.
+func (s *Song) IsLive() bool {
+	return s.Source != nil && s.Source.Live()
+}
@@ -41,6 +41,8 @@
           DATABASE = cfg.databasePath;
           WORDLE_ANSWERS_PATH = "${cfg.package}/share/soosa/wordlist_answers.txt";
           WORDLE_ALLOWED_PATH = "${cfg.package}/share/soosa/wordlist_allowed.txt";
+          WORDLE_PACKS_DIR = "${cfg.package}/share/soosa/wordle_packs";
+          TRIVIA_QUESTIONS_PATH = "${cfg.package}/share/soosa/trivia_questions.json";
         } // cfg.extraEnvironment;
 
         serviceConfig = {
@@ -71,6 +73,8 @@
       postInstall = ''
         mkdir -p $out/share/soosa
         cp wordlist_*.txt $out/share/soosa/ || true
+        cp -r wordle_packs $out/share/soosa/ || true
+        cp trivia_questions.json $out/share/soosa/ || true
       '';
 
       meta = with lib; {